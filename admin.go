@@ -0,0 +1,166 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// AdminState is the JSON document served and accepted by the admin handler
+type AdminState struct {
+	Level    string            `json:"level"`
+	Packages map[string]string `json:"packages"`
+	Loggers  map[string]string `json:"loggers"`
+	Quiet    bool              `json:"quiet"`
+	Sinks    []string          `json:"sinks"`
+}
+
+// adminUpdate is the body of a PUT request. Absent fields are left alone and
+// a package or logger name mapped to an empty level drops its override.
+type adminUpdate struct {
+	Level    *string           `json:"level"`
+	Packages map[string]string `json:"packages"`
+	Loggers  map[string]string `json:"loggers"`
+	Quiet    *bool             `json:"quiet"`
+}
+
+// AdminHandler returns an http.Handler to mount on an internal admin port.
+// GET returns the current level, per package and logger name overrides, quiet
+// state and active sinks as JSON, PUT changes level, overrides and quiet state
+// of the running logger.
+func (l *Logger) AdminHandler() http.Handler {
+	return adminHandler{logger: l}
+}
+
+type adminHandler struct {
+	logger *Logger
+}
+
+func (h adminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if err := h.update(r); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "only GET and PUT are supported"})
+		return
+	}
+	writeJSON(w, http.StatusOK, h.logger.AdminState())
+}
+
+// update validates the whole request before changing anything, so a bad
+// request leaves the logger untouched
+func (h adminHandler) update(r *http.Request) error {
+	var req adminUpdate
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		return fmt.Errorf("invalid request body: %s", err)
+	}
+
+	var level MessageType
+	if req.Level != nil {
+		var err error
+		if level, err = ParseLevel(*req.Level); err != nil {
+			return err
+		}
+	}
+
+	packages, err := parseLevels("package", req.Packages)
+	if err != nil {
+		return err
+	}
+	loggers, err := parseLevels("logger", req.Loggers)
+	if err != nil {
+		return err
+	}
+
+	if req.Level != nil {
+		h.logger.SetLevel(level)
+	}
+	for pkg, name := range req.Packages {
+		if len(name) == 0 {
+			h.logger.ClearPackageLevel(pkg)
+		} else {
+			h.logger.SetPackageLevel(pkg, packages[pkg])
+		}
+	}
+	for logger, name := range req.Loggers {
+		if len(name) == 0 {
+			h.logger.ClearLoggerLevel(logger)
+		} else {
+			h.logger.SetLoggerLevel(logger, loggers[logger])
+		}
+	}
+	if req.Quiet != nil {
+		h.logger.SetQuiet(*req.Quiet)
+	}
+	return nil
+}
+
+// parseLevels parses the level overrides of a request, skipping the empty
+// levels that drop an override
+func parseLevels(kind string, names map[string]string) (map[string]MessageType, error) {
+	levels := make(map[string]MessageType, len(names))
+	for key, name := range names {
+		if len(name) == 0 {
+			continue
+		}
+		level, err := ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %s", kind, key, err)
+		}
+		levels[key] = level
+	}
+	return levels, nil
+}
+
+// AdminState returns the runtime state reported by the admin handler
+func (l *Logger) AdminState() AdminState {
+	state := AdminState{
+		Level:    l.Level().Name(),
+		Packages: make(map[string]string),
+		Loggers:  make(map[string]string),
+		Quiet:    l.IsQuiet(),
+	}
+	for pkg, level := range l.PackageLevels() {
+		state.Packages[pkg] = level.Name()
+	}
+	for name, level := range l.LoggerLevels() {
+		state.Loggers[name] = level.Name()
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, out := range l.out {
+		state.Sinks = append(state.Sinks, sinkName(out))
+	}
+	for _, hook := range l.hooks {
+		state.Sinks = append(state.Sinks, fmt.Sprintf("hook:%T", hook))
+	}
+	return state
+}
+
+func sinkName(out FdWriter) string {
+	if plain, ok := out.(plainWriter); ok {
+		out = plain.FdWriter
+	}
+	if f, ok := out.(*os.File); ok {
+		return f.Name()
+	}
+	return fmt.Sprintf("%T", out)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,79 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the admin handler
+
+package log_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/logtest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAdminHandler(t *testing.T) {
+	Convey("Given a logger at info level served by the admin handler", t, func() {
+		logger, rec := logtest.New(t)
+		logger.SetLevel(log.Info)
+		handler := logger.AdminHandler()
+
+		serve := func(method, body string) (int, log.AdminState) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(method, "/log", strings.NewReader(body)))
+			var state log.AdminState
+			json.Unmarshal(w.Body.Bytes(), &state)
+			return w.Code, state
+		}
+
+		Convey("When the state is requested", func() {
+			code, state := serve(http.MethodGet, "")
+
+			Convey("It should report level, quiet state and sinks", func() {
+				So(code, ShouldEqual, http.StatusOK)
+				So(state.Level, ShouldEqual, "info")
+				So(state.Quiet, ShouldBeFalse)
+				So(state.Sinks, ShouldContain, "*logtest.Writer")
+			})
+		})
+
+		Convey("When debug is enabled for this package only", func() {
+			code, state := serve(http.MethodPut, `{"packages": {"github.com/rish1988/go-log_test": "debug"}}`)
+			logger.Debug("package debug")
+
+			Convey("It should write debug messages logged from the package", func() {
+				So(code, ShouldEqual, http.StatusOK)
+				So(state.Level, ShouldEqual, "info")
+				So(state.Packages["github.com/rish1988/go-log_test"], ShouldEqual, "debug")
+				rec.AssertLogged(t, log.Debug, "package debug")
+			})
+		})
+
+		Convey("When the level is raised and the logger quieted", func() {
+			code, state := serve(http.MethodPut, `{"level": "error", "quiet": true}`)
+
+			Convey("It should apply both", func() {
+				So(code, ShouldEqual, http.StatusOK)
+				So(state.Level, ShouldEqual, "error")
+				So(state.Quiet, ShouldBeTrue)
+				So(logger.IsEnabled(log.Warn), ShouldBeFalse)
+				So(logger.IsQuiet(), ShouldBeTrue)
+			})
+		})
+
+		Convey("When an unknown level is requested", func() {
+			code, _ := serve(http.MethodPut, `{"level": "loud", "quiet": true}`)
+
+			Convey("It should reject the request without changes", func() {
+				So(code, ShouldEqual, http.StatusBadRequest)
+				So(logger.Level(), ShouldEqual, log.Info)
+				So(logger.IsQuiet(), ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -16,6 +16,11 @@ func (b *Buffer) Append(data []byte) {
 	*b = append(*b, data...)
 }
 
+// AppendString append the bytes of a string to buffer
+func (b *Buffer) AppendString(data string) {
+	*b = append(*b, data...)
+}
+
 // AppendByte to buffer
 func (b *Buffer) AppendByte(data byte) {
 	*b = append(*b, data)
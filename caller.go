@@ -0,0 +1,180 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rish1988/go-log/buffer"
+)
+
+// Caller formats, see config.LogOptions.CallerFormat
+const (
+	callerFull     = "full"
+	callerShort    = "short"
+	callerRelative = "relative"
+	callerLong     = "long"
+	callerFunc     = "func"
+	callerPackage  = "package"
+)
+
+// frame is the symbol information of a program counter, looked up once and
+// cached since FuncForPC is expensive
+type frame struct {
+	// Function is the full function name, e.g. "github.com/a/b.(*T).Method"
+	function string
+	// pkg is the import path of the function package, e.g. "github.com/a/b"
+	pkg string
+	// pkgFunc is the function name qualified by the package name only,
+	// e.g. "b.(*T).Method"
+	pkgFunc string
+	// name is the bare function name, e.g. "(*T).Method"
+	name string
+	// relative is the file path relative to the main module root
+	relative string
+}
+
+var (
+	// frames caches *frame by program counter
+	frames sync.Map
+
+	// helpers holds the names of the functions marked by Helper
+	helpers    sync.Map
+	hasHelpers atomic.Bool
+
+	unknownFrame = &frame{
+		function: "<unknown function>",
+		pkgFunc:  "<unknown function>",
+		name:     "<unknown function>",
+		relative: "<unknown file>",
+	}
+
+	// mainModule is the module path of the running program
+	mainModule = func() string {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			return info.Main.Path
+		}
+		return ""
+	}()
+)
+
+// callerFrame returns the caller skip frames above its own caller together
+// with its cached symbol information. Frames of functions marked by Helper
+// are skipped as well.
+func callerFrame(skip int) (Caller, *frame) {
+	for {
+		pc, file, line, ok := runtime.Caller(skip + 1)
+		if !ok {
+			return Caller{Function: unknownFrame.function, File: "<unknown file>"}, unknownFrame
+		}
+		f := lookupFrame(pc, file)
+		if !isHelper(f.function) {
+			return Caller{Function: f.function, File: file, Line: line}, f
+		}
+		skip++
+	}
+}
+
+// lookupFrame returns the cached frame of pc, adding it when missing
+func lookupFrame(pc uintptr, file string) *frame {
+	if f, ok := frames.Load(pc); ok {
+		return f.(*frame)
+	}
+
+	f := &frame{function: "<unknown function>"}
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		f.function = fn.Name()
+	}
+	f.pkg = funcPackage(f.function)
+	f.pkgFunc = f.function[strings.LastIndexByte(f.function, '/')+1:]
+	f.name = f.pkgFunc[strings.IndexByte(f.pkgFunc, '.')+1:]
+
+	// Packages of the main module are shown relative to its root, others
+	// by their import path. External test packages live next to the
+	// package they test.
+	dir := strings.TrimSuffix(f.pkg, "_test")
+	if len(mainModule) != 0 && mainModule != "command-line-arguments" {
+		if dir == mainModule {
+			dir = ""
+		} else {
+			dir = strings.TrimPrefix(dir, mainModule+"/")
+		}
+	}
+	f.relative = filepath.Base(file)
+	if len(dir) != 0 {
+		f.relative = dir + "/" + f.relative
+	}
+
+	actual, _ := frames.LoadOrStore(pc, f)
+	return actual.(*frame)
+}
+
+// appendCaller append caller in format to buf
+func appendCaller(buf *buffer.Buffer, format string, caller Caller, f *frame) {
+	switch format {
+	case callerFunc:
+		buf.AppendString(f.name)
+		return
+	case callerPackage:
+		buf.AppendString(f.pkgFunc)
+		return
+	case callerShort:
+		buf.AppendString(filepath.Base(caller.File))
+	case callerRelative:
+		buf.AppendString(f.relative)
+	case callerLong:
+		buf.AppendString(caller.File)
+	default:
+		buf.AppendString(f.function)
+		buf.AppendByte(':')
+		buf.AppendString(filepath.Base(caller.File))
+	}
+	buf.AppendByte(':')
+	buf.AppendInt(caller.Line, 0)
+}
+
+// SetCaller turn the caller info of level on or off for this logger and the
+// loggers derived from it, which share its prefixes
+func (l *Logger) SetCaller(level MessageType, enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if level >= Fatal && level <= Trace {
+		l.prefixes[level].File = enabled
+	}
+}
+
+// WithCallerSkip returns a logger sharing the output and settings of l that
+// reports the caller n frames further up the stack, for loggers used by
+// wrapper functions
+func (l *Logger) WithCallerSkip(n int) *Logger {
+	derived := *l
+	derived.callerSkip += n
+	return &derived
+}
+
+// Helper marks the calling function as a logging helper, like
+// testing.T.Helper. Records logged from within a helper report the caller of
+// the helper instead.
+func Helper() {
+	pc, file, _, ok := runtime.Caller(1)
+	if !ok {
+		return
+	}
+	helpers.Store(lookupFrame(pc, file).function, struct{}{})
+	hasHelpers.Store(true)
+}
+
+// isHelper check whether function was marked by Helper
+func isHelper(function string) bool {
+	if !hasHelpers.Load() {
+		return false
+	}
+	_, ok := helpers.Load(function)
+	return ok
+}
@@ -0,0 +1,88 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the caller info
+
+package log_test
+
+import (
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCaller(t *testing.T) {
+	Convey("Given loggers with caller info on info records", t, func() {
+		for format, expect := range map[string]string{
+			"full":     "[INFO]  github.com/rish1988/go-log_test.TestCaller.func1:caller_test.go:33 hello\n",
+			"short":    "[INFO]  caller_test.go:33 hello\n",
+			"relative": "[INFO]  caller_test.go:33 hello\n",
+			"func":     "[INFO]  TestCaller.func1 hello\n",
+			"package":  "[INFO]  go-log_test.TestCaller.func1 hello\n",
+		} {
+			out := &pipe{}
+			logger, err := log.NewLogger(
+				log.WithOutput(out),
+				log.WithCaller(log.Info, true),
+				log.WithCallerFormat(format),
+			)
+			So(err, ShouldBeNil)
+
+			logger.Info("hello")
+			So(out.String(), ShouldEqual, expect)
+		}
+	})
+
+	Convey("Given two loggers writing errors", t, func() {
+		out, other := &pipe{}, &pipe{}
+		logger := log.New(log.NewFdWriters(out), config.LogOptions{})
+		log.New(log.NewFdWriters(other), config.LogOptions{}).Error("first")
+
+		Convey("When caller info is turned off for errors on one of them", func() {
+			logger.SetCaller(log.Error, false)
+			logger.Error("failed")
+
+			Convey("It should only change that logger", func() {
+				So(out.String(), ShouldEqual, "[ERROR] failed\n")
+				So(other.String(), ShouldContainSubstring, "caller_test.go")
+			})
+		})
+	})
+}
+
+// logFailure is a wrapper reporting its caller through WithCallerSkip
+func logFailure(logger *log.Logger, err string) {
+	logger.WithCallerSkip(1).Errorf("failure: %s", err)
+}
+
+// logMarked is a wrapper marked as helper
+func logMarked(logger *log.Logger, err string) {
+	log.Helper()
+	logger.Errorf("marked: %s", err)
+}
+
+func TestCallerSkip(t *testing.T) {
+	Convey("Given a logger with short caller info", t, func() {
+		out := &pipe{}
+		logger, err := log.NewLogger(log.WithOutput(out), log.WithCallerFormat("short"))
+		So(err, ShouldBeNil)
+
+		Convey("When logging through a wrapper skipping one frame", func() {
+			logFailure(logger, "timeout")
+
+			Convey("It should report the caller of the wrapper", func() {
+				So(out.String(), ShouldEqual, "[ERROR] caller_test.go:73 failure: timeout\n")
+			})
+		})
+
+		Convey("When logging through a function marked as helper", func() {
+			logMarked(logger, "timeout")
+
+			Convey("It should report the caller of the helper", func() {
+				So(out.String(), ShouldEqual, "[ERROR] caller_test.go:81 marked: timeout\n")
+			})
+		})
+	})
+}
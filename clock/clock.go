@@ -0,0 +1,47 @@
+// Time source for the go-log library
+
+package clock
+
+import "time"
+
+// Clock is the source of time for timestamps, log file names and rotation
+// schedules. It can be replaced to make them deterministic in tests.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is the subset of time.Timer used by the library
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// System is the Clock backed by the system wall clock
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// OrSystem returns c, or the System clock when c is nil
+func OrSystem(c Clock) Clock {
+	if c == nil {
+		return System
+	}
+	return c
+}
@@ -0,0 +1,109 @@
+// Fake time source for testing code built on the go-log library
+
+package clocktest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rish1988/go-log/clock"
+)
+
+// Fake is a clock.Clock that only moves when told to. Advancing it fires the
+// timers that became due, so rotation can be triggered instantly.
+type Fake struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock    *Fake
+	deadline time.Time
+	c        chan time.Time
+}
+
+// NewFake returns a fake clock set to now
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+// Now returns the current fake time
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// NewTimer returns a timer firing once the fake time has been advanced by d
+func (f *Fake) NewTimer(d time.Duration) clock.Timer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t := &fakeTimer{clock: f, deadline: f.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- f.now
+		return t
+	}
+	f.timers = append(f.timers, t)
+	f.cond.Broadcast()
+	return t
+}
+
+// Advance moves the fake time forward by d and fires the due timers
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	f.set(f.now.Add(d))
+	f.mu.Unlock()
+}
+
+// Set moves the fake time to now and fires the due timers
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	f.set(now)
+	f.mu.Unlock()
+}
+
+func (f *Fake) set(now time.Time) {
+	f.now = now
+	pending := f.timers[:0]
+	for _, t := range f.timers {
+		if now.Before(t.deadline) {
+			pending = append(pending, t)
+		} else {
+			t.c <- now
+		}
+	}
+	f.timers = pending
+}
+
+// BlockUntil waits until at least n timers are pending, which lets a test
+// make sure a scheduler is waiting before advancing the clock
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.timers) < n {
+		f.cond.Wait()
+	}
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	f := t.clock
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i, pending := range f.timers {
+		if pending == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
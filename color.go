@@ -0,0 +1,94 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"os"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// ColorEnabled decides whether w gets colored output when color is not
+// forced on or off. The conventions are checked in order:
+//
+//   - NO_COLOR set to any non-empty value disables color
+//   - FORCE_COLOR set to a non-empty value other than "0" or "false" enables
+//     color, "0" or "false" disables it
+//   - CLICOLOR_FORCE set to a non-empty value other than "0" enables color
+//   - TERM=dumb or CLICOLOR=0 disables color
+//
+// Otherwise color is used on terminals only.
+func ColorEnabled(w FdWriter) bool {
+	if len(os.Getenv("NO_COLOR")) != 0 {
+		return false
+	}
+	if force := os.Getenv("FORCE_COLOR"); len(force) != 0 {
+		return force != "0" && force != "false"
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); len(force) != 0 && force != "0" {
+		return true
+	}
+	if os.Getenv("TERM") == "dumb" || os.Getenv("CLICOLOR") == "0" {
+		return false
+	}
+	return terminal.IsTerminal(int(w.Fd()))
+}
+
+// plainWriter marks a writer that never gets colored output, not even when
+// color is forced, such as the log file
+type plainWriter struct {
+	FdWriter
+}
+
+// isPlain check whether w never gets colored output
+func isPlain(w FdWriter) bool {
+	_, ok := w.(plainWriter)
+	return ok
+}
+
+// colorWriters decides once per writer whether it gets colored output, the
+// log file never does whatever the environment says
+func colorWriters(out FdWriters) []bool {
+	colored := make([]bool, len(out))
+	for i, w := range out {
+		colored[i] = !isPlain(w) && ColorEnabled(w)
+	}
+	return colored
+}
+
+// anyColored check whether at least one writer gets colored output
+func anyColored(colored []bool) bool {
+	for _, c := range colored {
+		if c {
+			return true
+		}
+	}
+	return false
+}
+
+// writeColored write t to the writers getting colored output in mode and p
+// to the others, skipping the writers not taking the lines of the logger
+// named name. In auto mode colored holds the decision of every writer.
+func (f *FdWriters) writeColored(name string, mode colorMode, colored []bool, t []byte, p []byte) (n int, err error) {
+	for i, writer := range *f {
+		if !accepts(writer, name) {
+			continue
+		}
+		data := p
+		switch mode {
+		case colorAlways:
+			if !isPlain(writer) {
+				data = t
+			}
+		case colorAuto:
+			if i < len(colored) && colored[i] {
+				data = t
+			}
+		}
+		if n, err = writer.Write(data); err != nil {
+			return n, err
+		}
+	}
+	return len(t), nil
+}
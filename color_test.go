@@ -0,0 +1,118 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the color detection
+
+package log_test
+
+import (
+	"os"
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// colorEnv are the variables read by log.ColorEnabled
+var colorEnv = []string{"NO_COLOR", "FORCE_COLOR", "CLICOLOR_FORCE", "CLICOLOR", "TERM"}
+
+// setColorEnv replaces the color variables by env until the end of the test
+func setColorEnv(t *testing.T, env map[string]string) {
+	for _, name := range colorEnv {
+		// Setenv restores the variable once the test is done
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+	for name, v := range env {
+		t.Setenv(name, v)
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	Convey("Given a writer that is not a terminal", t, func() {
+		out := &pipe{}
+
+		for _, c := range []struct {
+			env    map[string]string
+			expect bool
+		}{
+			{map[string]string{}, false},
+			{map[string]string{"FORCE_COLOR": "1"}, true},
+			{map[string]string{"FORCE_COLOR": ""}, false},
+			{map[string]string{"FORCE_COLOR": "0"}, false},
+			{map[string]string{"FORCE_COLOR": "1", "NO_COLOR": "1"}, false},
+			{map[string]string{"NO_COLOR": ""}, false},
+			{map[string]string{"CLICOLOR_FORCE": "1"}, true},
+			{map[string]string{"CLICOLOR_FORCE": "1", "TERM": "dumb"}, true},
+		} {
+			Convey("When the environment is "+fmtEnv(c.env), func() {
+				setColorEnv(t, c.env)
+
+				Convey("It should decide color accordingly", func() {
+					So(log.ColorEnabled(out), ShouldEqual, c.expect)
+				})
+			})
+		}
+	})
+
+	Convey("Given FORCE_COLOR is set when a logger is created", t, func() {
+		setColorEnv(t, map[string]string{"FORCE_COLOR": "1"})
+		out := &pipe{}
+		logger := log.New(log.NewFdWriters(out), config.LogOptions{})
+
+		Convey("When it is unset before logging", func() {
+			os.Unsetenv("FORCE_COLOR")
+			logger.Info("colored")
+
+			Convey("It should keep the decision made at construction", func() {
+				So(out.String(), ShouldContainSubstring, "\033[0;32m")
+			})
+		})
+
+		Convey("When color is disabled in the options", func() {
+			logger = log.New(log.NewFdWriters(out), config.LogOptions{ColorOptions: config.ColorOptions{NoColor: true}})
+			logger.Info("plain")
+
+			Convey("It should override the environment", func() {
+				So(out.String(), ShouldEqual, "[INFO]  plain\n")
+			})
+		})
+	})
+
+	Convey("Given writers written directly", t, func() {
+		out := &pipe{}
+		writers := log.NewFdWriters(out)
+
+		Convey("When color is forced by the environment", func() {
+			setColorEnv(t, map[string]string{"FORCE_COLOR": "1"})
+			writers.Write([]byte("colored"), []byte("plain"))
+
+			Convey("It should write the colored data", func() {
+				So(out.String(), ShouldEqual, "colored")
+			})
+		})
+
+		Convey("When color is disabled by the environment", func() {
+			setColorEnv(t, map[string]string{"NO_COLOR": "1"})
+			writers.Write([]byte("colored"), []byte("plain"))
+
+			Convey("It should write the plain data", func() {
+				So(out.String(), ShouldEqual, "plain")
+			})
+		})
+	})
+}
+
+func fmtEnv(env map[string]string) string {
+	if len(env) == 0 {
+		return "empty"
+	}
+	s := ""
+	for _, name := range colorEnv {
+		if v, ok := env[name]; ok {
+			s += name + "=" + v + " "
+		}
+	}
+	return s
+}
@@ -4,6 +4,9 @@
 package colorful
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/rish1988/go-log/buffer"
 )
 
@@ -106,3 +109,40 @@ func Cyan(data []byte) []byte {
 func Gray(data []byte) []byte {
 	return mixer(data, colorGray)
 }
+
+// names map the color names accepted in configuration to their color
+var names = map[string]Color{
+	"red":     Red,
+	"green":   Green,
+	"orange":  Orange,
+	"yellow":  Orange,
+	"blue":    Blue,
+	"purple":  Purple,
+	"magenta": Purple,
+	"cyan":    Cyan,
+	"gray":    Gray,
+	"grey":    Gray,
+	"black":   Black,
+	"white":   White,
+}
+
+// ByName returns the color with the given name, e.g. "red" or "cyan",
+// written in hex, e.g. "#ff8800", or the style written as words, e.g.
+// "bold white on red"
+func ByName(name string) (Color, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if color, ok := names[name]; ok {
+		return color, nil
+	}
+	if strings.HasPrefix(name, "#") {
+		return Hex(name)
+	}
+	if strings.ContainsRune(name, ' ') || attributes[name] != nil {
+		style, err := ParseStyle(name)
+		if err != nil {
+			return nil, err
+		}
+		return style.Color(), nil
+	}
+	return nil, fmt.Errorf("unknown color [ %s ]", name)
+}
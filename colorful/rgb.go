@@ -0,0 +1,265 @@
+// The color engine for the go-log library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package colorful
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Profile is the set of colors a terminal can show
+type Profile int32
+
+const (
+	// Basic terminals show the 16 standard colors
+	Basic Profile = iota
+	// Extended terminals show the 256 color palette
+	Extended
+	// TrueColor terminals show any 24 bit color
+	TrueColor
+)
+
+// profile is the profile RGB and ANSI256 colors are downgraded to
+var profile atomic.Int32
+
+func init() {
+	SetProfile(DetectProfile())
+}
+
+// DetectProfile derive the profile of the terminal from the COLORTERM and
+// TERM environment variables
+func DetectProfile() Profile {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return Extended
+	}
+	return Basic
+}
+
+// SetProfile override the detected profile
+func SetProfile(p Profile) {
+	profile.Store(int32(p))
+}
+
+// CurrentProfile returns the profile colors are downgraded to
+func CurrentProfile() Profile {
+	return Profile(profile.Load())
+}
+
+// rgb is a 24 bit color
+type rgb struct {
+	r, g, b uint8
+}
+
+// basicPalette holds the usual values of the 16 standard colors, indexed by
+// their ANSI number
+var basicPalette = [16]rgb{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel values of the 6x6x6 color cube of the 256 color
+// palette
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// sequences are the escape sequences of a color for each profile
+type sequences [TrueColor + 1][]byte
+
+// current returns the sequence for the current profile
+func (s *sequences) current() []byte {
+	return s[validProfile(CurrentProfile())]
+}
+
+// color returns a Color applying the sequence for the current profile
+func (s *sequences) color() Color {
+	return func(data []byte) []byte {
+		return mixer(data, s.current())
+	}
+}
+
+func validProfile(p Profile) Profile {
+	if p < Basic || p > TrueColor {
+		return Basic
+	}
+	return p
+}
+
+// appendRGB append the escape sequence of c for profile p to buf
+func appendRGB(buf []byte, c rgb, p Profile) []byte {
+	switch validProfile(p) {
+	case TrueColor:
+		buf = append(buf, "\033[38;2;"...)
+		buf = strconv.AppendUint(buf, uint64(c.r), 10)
+		buf = append(buf, ';')
+		buf = strconv.AppendUint(buf, uint64(c.g), 10)
+		buf = append(buf, ';')
+		buf = strconv.AppendUint(buf, uint64(c.b), 10)
+		return append(buf, 'm')
+	case Extended:
+		return append256(buf, nearest256(c))
+	}
+	return appendBasic(buf, nearestBasic(c))
+}
+
+// appendANSI256 append the escape sequence of palette color n for profile p
+// to buf
+func appendANSI256(buf []byte, n uint8, p Profile) []byte {
+	if validProfile(p) == Basic {
+		return appendBasic(buf, nearestBasic(paletteColor(n)))
+	}
+	return append256(buf, n)
+}
+
+func append256(buf []byte, n uint8) []byte {
+	buf = append(buf, "\033[38;5;"...)
+	buf = strconv.AppendUint(buf, uint64(n), 10)
+	return append(buf, 'm')
+}
+
+// appendBasic append the escape sequence of standard color n to buf
+func appendBasic(buf []byte, n int) []byte {
+	code := 30 + n
+	if n >= 8 {
+		code = 90 + n - 8
+	}
+	buf = append(buf, "\033[0;"...)
+	buf = strconv.AppendInt(buf, int64(code), 10)
+	return append(buf, 'm')
+}
+
+func rgbSequences(c rgb) *sequences {
+	var s sequences
+	for p := range s {
+		s[p] = appendRGB(nil, c, Profile(p))
+	}
+	return &s
+}
+
+func ansi256Sequences(n uint8) *sequences {
+	var s sequences
+	for p := range s {
+		s[p] = appendANSI256(nil, n, Profile(p))
+	}
+	return &s
+}
+
+// RGB returns the 24 bit color r, g, b, downgraded to the nearest color of
+// the palette on terminals without true color support
+func RGB(r, g, b uint8) Color {
+	return rgbSequences(rgb{r, g, b}).color()
+}
+
+// ANSI256 returns color n of the 256 color palette, downgraded to the nearest
+// standard color on basic terminals
+func ANSI256(n uint8) Color {
+	return ansi256Sequences(n).color()
+}
+
+// Hex returns the color written as "#rrggbb" or "#rgb"
+func Hex(hex string) (Color, error) {
+	c, err := parseHex(hex)
+	if err != nil {
+		return nil, err
+	}
+	return rgbSequences(c).color(), nil
+}
+
+func parseHex(hex string) (rgb, error) {
+	digits := strings.TrimPrefix(hex, "#")
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	v, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil || len(digits) != 6 {
+		return rgb{}, fmt.Errorf("invalid hex color [ %s ], expected #rrggbb", hex)
+	}
+	return rgb{uint8(v >> 16), uint8(v >> 8), uint8(v)}, nil
+}
+
+// RGB apply the 24 bit color r, g, b to the data, downgraded like RGB
+func (cb *ColorBuffer) RGB(r, g, b uint8) {
+	cb.Buffer = appendRGB(cb.Buffer, rgb{r, g, b}, CurrentProfile())
+}
+
+// ANSI256 apply color n of the 256 color palette to the data, downgraded
+// like ANSI256
+func (cb *ColorBuffer) ANSI256(n uint8) {
+	cb.Buffer = appendANSI256(cb.Buffer, n, CurrentProfile())
+}
+
+// paletteColor returns the value of color n of the 256 color palette
+func paletteColor(n uint8) rgb {
+	switch {
+	case n < 16:
+		return basicPalette[n]
+	case n < 232:
+		n -= 16
+		return rgb{cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]}
+	}
+	gray := 8 + 10*(n-232)
+	return rgb{gray, gray, gray}
+}
+
+// nearest256 returns the color cube or gray ramp entry closest to c, leaving
+// out the standard colors whose values vary between terminals
+func nearest256(c rgb) uint8 {
+	r, g, b := nearestLevel(c.r), nearestLevel(c.g), nearestLevel(c.b)
+	cube := 16 + 36*r + 6*g + b
+
+	avg := (int(c.r) + int(c.g) + int(c.b)) / 3
+	step := (avg - 3) / 10
+	if step < 0 {
+		step = 0
+	} else if step > 23 {
+		step = 23
+	}
+	gray := uint8(232 + step)
+
+	if distance(c, paletteColor(gray)) < distance(c, paletteColor(cube)) {
+		return gray
+	}
+	return cube
+}
+
+// nearestLevel returns the index of the color cube level closest to v
+func nearestLevel(v uint8) uint8 {
+	best := uint8(0)
+	for i, level := range cubeLevels {
+		if absDiff(v, level) < absDiff(v, cubeLevels[best]) {
+			best = uint8(i)
+		}
+	}
+	return best
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// nearestBasic returns the number of the standard color closest to c
+func nearestBasic(c rgb) int {
+	best, bestDistance := 0, -1
+	for n, p := range basicPalette {
+		if d := distance(c, p); bestDistance < 0 || d < bestDistance {
+			best, bestDistance = n, d
+		}
+	}
+	return best
+}
+
+func distance(a, b rgb) int {
+	dr, dg, db := int(a.r)-int(b.r), int(a.g)-int(b.g), int(a.b)-int(b.b)
+	return dr*dr + dg*dg + db*db
+}
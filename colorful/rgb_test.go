@@ -0,0 +1,79 @@
+// The color engine for the go-log library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for 256 and true colors
+
+package colorful
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRGB(t *testing.T) {
+	Convey("Given the detected profile is restored afterwards", t, func() {
+		detected := CurrentProfile()
+		Reset(func() {
+			SetProfile(detected)
+		})
+		data := []byte("data")
+
+		Convey("When the terminal supports true color", func() {
+			SetProfile(TrueColor)
+
+			Convey("It should write 24 bit and 256 color sequences as is", func() {
+				So(string(RGB(255, 136, 0)(data)), ShouldEqual, "\033[38;2;255;136;0mdata\033[0m")
+				So(string(ANSI256(208)(data)), ShouldEqual, "\033[38;5;208mdata\033[0m")
+			})
+		})
+
+		Convey("When the terminal supports 256 colors", func() {
+			SetProfile(Extended)
+
+			Convey("It should use the nearest palette entry", func() {
+				So(string(RGB(255, 136, 0)(data)), ShouldEqual, "\033[38;5;208mdata\033[0m")
+				So(string(RGB(128, 128, 128)(data)), ShouldEqual, "\033[38;5;244mdata\033[0m")
+			})
+		})
+
+		Convey("When the terminal supports the standard colors only", func() {
+			SetProfile(Basic)
+
+			Convey("It should use the nearest standard color", func() {
+				So(string(RGB(250, 10, 10)(data)), ShouldEqual, "\033[0;91mdata\033[0m")
+				So(string(ANSI256(34)(data)), ShouldEqual, "\033[0;32mdata\033[0m")
+			})
+		})
+
+		Convey("When colors are applied to a buffer", func() {
+			SetProfile(Extended)
+			var cb ColorBuffer
+			cb.RGB(255, 136, 0)
+			cb.ANSI256(34)
+
+			Convey("It should append the same sequences", func() {
+				So(string(cb.Buffer), ShouldEqual, "\033[38;5;208m\033[38;5;34m")
+			})
+		})
+	})
+
+	Convey("Given hex colors", t, func() {
+		Convey("It should parse the long and short forms", func() {
+			for _, hex := range []string{"#ff8800", "#f80", "FF8800"} {
+				c, err := Hex(hex)
+				So(err, ShouldBeNil)
+				So(c, ShouldNotBeNil)
+			}
+			_, err := ByName("#ff8800")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("It should reject malformed ones", func() {
+			for _, hex := range []string{"#ff88", "#gg8800", ""} {
+				_, err := Hex(hex)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
@@ -0,0 +1,160 @@
+// The color engine for the go-log library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package colorful
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+var (
+	colorBlack = []byte("\033[0;30m")
+	colorWhite = []byte("\033[0;97m")
+)
+
+// Black apply black color to the data
+func Black(data []byte) []byte {
+	return mixer(data, colorBlack)
+}
+
+// White apply white color to the data
+func White(data []byte) []byte {
+	return mixer(data, colorWhite)
+}
+
+// Black apply black color to the data
+func (cb *ColorBuffer) Black() {
+	cb.Append(colorBlack)
+}
+
+// White apply white color to the data
+func (cb *ColorBuffer) White() {
+	cb.Append(colorWhite)
+}
+
+// Style combines a foreground and background color with text attributes,
+// e.g. Style{FG: White, BG: Red, Bold: true}
+type Style struct {
+	// FG and BG are colors of this package, such as Red, RGB(...) or
+	// ANSI256(...)
+	FG, BG    Color
+	Bold      bool
+	Dim       bool
+	Italic    bool
+	Underline bool
+	Inverse   bool
+}
+
+// Apply style to the data. Use Color to apply the same style repeatedly.
+func (s Style) Apply(data []byte) []byte {
+	return mixer(data, s.sequence())
+}
+
+// Color returns the style as a Color, usable wherever a color is expected.
+// Like the colors of RGB and ANSI256 it follows the current profile.
+func (s Style) Color() Color {
+	return func(data []byte) []byte {
+		return mixer(data, s.sequence())
+	}
+}
+
+// Style apply style to the data
+func (cb *ColorBuffer) Style(s Style) {
+	cb.Append(s.sequence())
+}
+
+// sequence returns the escape sequence of the style. Colors are resolved for
+// the current profile.
+func (s Style) sequence() []byte {
+	params := []string{"0"}
+	for _, attr := range []struct {
+		on   bool
+		code string
+	}{
+		{s.Bold, "1"},
+		{s.Dim, "2"},
+		{s.Italic, "3"},
+		{s.Underline, "4"},
+		{s.Inverse, "7"},
+	} {
+		if attr.on {
+			params = append(params, attr.code)
+		}
+	}
+	if fg := colorParams(s.FG); len(fg) != 0 {
+		params = append(params, fg)
+	}
+	if bg := colorParams(s.BG); len(bg) != 0 {
+		params = append(params, background(bg))
+	}
+	return []byte("\033[" + strings.Join(params, ";") + "m")
+}
+
+// colorParams returns the parameters of the foreground sequence applied by
+// c, e.g. "31" for Red or "38;5;208" for ANSI256(208), leaving out the reset
+func colorParams(c Color) string {
+	if c == nil {
+		return ""
+	}
+	seq := c(nil)
+	seq = bytes.TrimSuffix(seq, colorOff)
+	if !bytes.HasPrefix(seq, []byte("\033[")) || !bytes.HasSuffix(seq, []byte("m")) {
+		return ""
+	}
+	params := string(seq[2 : len(seq)-1])
+	return strings.TrimPrefix(params, "0;")
+}
+
+// background turns foreground parameters into background ones
+func background(params string) string {
+	switch {
+	case strings.HasPrefix(params, "38;"):
+		return "48;" + params[3:]
+	case len(params) == 2 && params[0] == '3':
+		return "4" + params[1:]
+	case len(params) == 2 && params[0] == '9':
+		return "10" + params[1:]
+	}
+	return params
+}
+
+// attributes map the attribute names accepted by ParseStyle to their field
+var attributes = map[string]func(*Style){
+	"bold":      func(s *Style) { s.Bold = true },
+	"dim":       func(s *Style) { s.Dim = true },
+	"italic":    func(s *Style) { s.Italic = true },
+	"underline": func(s *Style) { s.Underline = true },
+	"inverse":   func(s *Style) { s.Inverse = true },
+}
+
+// ParseStyle parses a style written as words, e.g. "bold white on red": the
+// attributes bold, dim, italic, underline and inverse, a foreground color and
+// a background color following "on". Colors are names or hex values.
+func ParseStyle(spec string) (Style, error) {
+	var s Style
+	words := strings.Fields(strings.ToLower(spec))
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if set, ok := attributes[word]; ok {
+			set(&s)
+			continue
+		}
+
+		target := &s.FG
+		if word == "on" {
+			if i+1 == len(words) {
+				return Style{}, fmt.Errorf("missing background color in style [ %s ]", spec)
+			}
+			i++
+			word, target = words[i], &s.BG
+		}
+		color, err := ByName(word)
+		if err != nil {
+			return Style{}, fmt.Errorf("invalid style [ %s ]. Reason: %s", spec, err)
+		}
+		*target = color
+	}
+	return s, nil
+}
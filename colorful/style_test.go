@@ -0,0 +1,68 @@
+// The color engine for the go-log library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for styles
+
+package colorful
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStyle(t *testing.T) {
+	data := []byte("data")
+
+	Convey("Given styles combining colors and attributes", t, func() {
+		detected := CurrentProfile()
+		SetProfile(Extended)
+		Reset(func() {
+			SetProfile(detected)
+		})
+
+		Convey("It should write a single sequence", func() {
+			So(string(Style{FG: White, BG: Red, Bold: true}.Apply(data)), ShouldEqual, "\033[0;1;97;41mdata\033[0m")
+			So(string(Style{FG: Gray, Dim: true}.Apply(data)), ShouldEqual, "\033[0;2;37mdata\033[0m")
+			So(string(Style{Italic: true, Underline: true, Inverse: true}.Apply(data)), ShouldEqual, "\033[0;3;4;7mdata\033[0m")
+			So(string(Style{FG: ANSI256(208), BG: White}.Apply(data)), ShouldEqual, "\033[0;38;5;208;107mdata\033[0m")
+		})
+
+		Convey("It should apply the same way as Color and on buffers", func() {
+			style := Style{FG: Red, Bold: true}
+			So(string(style.Color()(data)), ShouldEqual, string(style.Apply(data)))
+
+			var cb ColorBuffer
+			cb.Style(style)
+			So(string(cb.Buffer), ShouldEqual, "\033[0;1;31m")
+		})
+
+		Convey("It should follow the profile set after Color was called", func() {
+			color := Style{FG: RGB(220, 50, 47), Bold: true}.Color()
+			SetProfile(TrueColor)
+			So(string(color(data)), ShouldEqual, "\033[0;1;38;2;220;50;47mdata\033[0m")
+			SetProfile(Basic)
+			So(string(color(data)), ShouldEqual, "\033[0;1;31mdata\033[0m")
+		})
+	})
+
+	Convey("Given styles written as words", t, func() {
+		Convey("It should parse attributes and colors", func() {
+			style, err := ParseStyle("bold white on red")
+			So(err, ShouldBeNil)
+			So(style.Bold, ShouldBeTrue)
+			So(string(style.Apply(data)), ShouldEqual, "\033[0;1;97;41mdata\033[0m")
+
+			color, err := ByName("dim gray")
+			So(err, ShouldBeNil)
+			So(string(color(data)), ShouldEqual, "\033[0;2;37mdata\033[0m")
+		})
+
+		Convey("It should reject unknown words", func() {
+			_, err := ParseStyle("bold chartreuse")
+			So(err, ShouldNotBeNil)
+			_, err = ParseStyle("white on")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
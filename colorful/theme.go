@@ -0,0 +1,150 @@
+// The color engine for the go-log library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package colorful
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Theme colors every part of a log line. A nil color leaves the part
+// uncolored.
+type Theme struct {
+	// Colors of the level prefixes and messages
+	Fatal, Error, Warn, Info, Debug, Trace Color
+	// Colors of the timestamp and the caller info
+	TimeStamp, Caller Color
+	// Colors of the field keys and values
+	FieldKey, FieldValue Color
+	// Names are the colors logger names are painted with, every name
+	// keeping the same color of the list
+	Names []Color
+}
+
+// Solarized palette, see https://ethanschoonover.com/solarized
+var (
+	solarizedBase01  = RGB(0x58, 0x6e, 0x75)
+	solarizedBase1   = RGB(0x93, 0xa1, 0xa1)
+	solarizedYellow  = RGB(0xb5, 0x89, 0x00)
+	solarizedOrange  = RGB(0xcb, 0x4b, 0x16)
+	solarizedRed     = RGB(0xdc, 0x32, 0x2f)
+	solarizedMagenta = RGB(0xd3, 0x36, 0x82)
+	solarizedViolet  = RGB(0x6c, 0x71, 0xc4)
+	solarizedBlue    = RGB(0x26, 0x8b, 0xd2)
+	solarizedCyan    = RGB(0x2a, 0xa1, 0x98)
+	solarizedGreen   = RGB(0x85, 0x99, 0x00)
+)
+
+// Okabe-Ito palette, distinguishable with every common color blindness
+var (
+	okabeOrange     = RGB(0xe6, 0x9f, 0x00)
+	okabeSkyBlue    = RGB(0x56, 0xb4, 0xe9)
+	okabeGreen      = RGB(0x00, 0x9e, 0x73)
+	okabeYellow     = RGB(0xf0, 0xe4, 0x42)
+	okabeBlue       = RGB(0x00, 0x72, 0xb2)
+	okabeVermillion = RGB(0xd5, 0x5e, 0x00)
+	okabePurple     = RGB(0xcc, 0x79, 0xa7)
+)
+
+var (
+	themesMu sync.RWMutex
+	themes   = map[string]Theme{
+		"default": {
+			Fatal:     Red,
+			Error:     Red,
+			Warn:      Orange,
+			Info:      Green,
+			Debug:     Purple,
+			Trace:     Cyan,
+			TimeStamp: Blue,
+			Caller:    Orange,
+			Names:     []Color{Green, Orange, Blue, Purple, Cyan},
+		},
+		"solarized": {
+			Fatal:      Style{FG: solarizedRed, Bold: true}.Color(),
+			Error:      solarizedRed,
+			Warn:       solarizedYellow,
+			Info:       solarizedGreen,
+			Debug:      solarizedViolet,
+			Trace:      solarizedBase01,
+			TimeStamp:  solarizedBlue,
+			Caller:     solarizedBase01,
+			FieldKey:   solarizedCyan,
+			FieldValue: solarizedBase1,
+			Names: []Color{solarizedBlue, solarizedCyan, solarizedGreen,
+				solarizedMagenta, solarizedOrange, solarizedViolet},
+		},
+		"high-contrast": {
+			Fatal:      Style{FG: White, BG: Red, Bold: true}.Color(),
+			Error:      Style{FG: RGB(0xff, 0x00, 0x00), Bold: true}.Color(),
+			Warn:       Style{FG: RGB(0xff, 0xff, 0x00), Bold: true}.Color(),
+			Info:       Style{FG: White, Bold: true}.Color(),
+			Debug:      RGB(0x00, 0xff, 0xff),
+			Trace:      White,
+			TimeStamp:  White,
+			Caller:     Style{FG: White, Underline: true}.Color(),
+			FieldKey:   Style{FG: RGB(0x00, 0xff, 0xff), Bold: true}.Color(),
+			FieldValue: White,
+			Names: []Color{RGB(0x00, 0xff, 0x00), RGB(0xff, 0xff, 0x00),
+				RGB(0x00, 0xff, 0xff), RGB(0xff, 0x00, 0xff)},
+		},
+		"colorblind-safe": {
+			Fatal:      Style{FG: okabeVermillion, Bold: true}.Color(),
+			Error:      okabeVermillion,
+			Warn:       okabeOrange,
+			Info:       okabeSkyBlue,
+			Debug:      okabePurple,
+			Trace:      Gray,
+			TimeStamp:  okabeBlue,
+			Caller:     Gray,
+			FieldKey:   okabeGreen,
+			FieldValue: okabeYellow,
+			Names: []Color{okabeOrange, okabeSkyBlue, okabeGreen,
+				okabeYellow, okabeBlue, okabePurple},
+		},
+		"monochrome": {
+			Fatal:     Style{Bold: true, Inverse: true}.Color(),
+			Error:     Style{Bold: true}.Color(),
+			Warn:      Style{Underline: true}.Color(),
+			Info:      Style{}.Color(),
+			Debug:     Style{Dim: true}.Color(),
+			Trace:     Style{Dim: true}.Color(),
+			TimeStamp: Style{Dim: true}.Color(),
+			Caller:    Style{Dim: true}.Color(),
+			FieldKey:  Style{Dim: true}.Color(),
+			Names:     []Color{Style{Bold: true}.Color()},
+		},
+	}
+)
+
+// ThemeByName returns the built-in or registered theme with the given name:
+// "default", "solarized", "high-contrast", "colorblind-safe" or "monochrome"
+func ThemeByName(name string) (Theme, error) {
+	themesMu.RLock()
+	defer themesMu.RUnlock()
+	if theme, ok := themes[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return theme, nil
+	}
+	return Theme{}, fmt.Errorf("unknown theme [ %s ]", name)
+}
+
+// RegisterTheme make theme selectable by name, replacing the theme already
+// registered under that name
+func RegisterTheme(name string, theme Theme) {
+	themesMu.Lock()
+	defer themesMu.Unlock()
+	themes[strings.ToLower(strings.TrimSpace(name))] = theme
+}
+
+// Sequence returns the escape sequence c starts the data with, to paint text
+// appended to a buffer without calling c for every line. The data must be
+// followed by the sequence of Off.
+func Sequence(c Color) []byte {
+	if c == nil {
+		return nil
+	}
+	return bytes.TrimSuffix(c(nil), colorOff)
+}
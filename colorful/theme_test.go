@@ -0,0 +1,77 @@
+// The color engine for the go-log library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the color themes
+
+package colorful
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTheme(t *testing.T) {
+	Convey("Given the built-in theme names", t, func() {
+		names := []string{"default", "solarized", "high-contrast", "colorblind-safe", "monochrome"}
+
+		Convey("It should find every theme with colors for every level", func() {
+			for _, name := range names {
+				theme, err := ThemeByName(name)
+				So(err, ShouldBeNil)
+				for _, c := range []Color{theme.Fatal, theme.Error, theme.Warn, theme.Info, theme.Debug, theme.Trace} {
+					So(c, ShouldNotBeNil)
+				}
+				So(theme.Names, ShouldNotBeEmpty)
+			}
+		})
+
+		Convey("It should paint styled and plain colors for the current profile", func() {
+			detected := CurrentProfile()
+			SetProfile(TrueColor)
+			defer SetProfile(detected)
+
+			theme, _ := ThemeByName("solarized")
+			So(string(Sequence(theme.Fatal)), ShouldEqual, "\033[0;1;38;2;220;50;47m")
+			So(string(Sequence(theme.Error)), ShouldEqual, "\033[38;2;220;50;47m")
+		})
+
+		Convey("It should ignore case and spaces", func() {
+			_, err := ThemeByName(" Solarized ")
+			So(err, ShouldBeNil)
+		})
+	})
+
+	Convey("Given an unknown theme name", t, func() {
+		_, err := ThemeByName("neon")
+
+		Convey("It should fail", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given a registered theme", t, func() {
+		RegisterTheme("Custom", Theme{Info: Blue})
+		Reset(func() {
+			themesMu.Lock()
+			delete(themes, "custom")
+			themesMu.Unlock()
+		})
+
+		Convey("It should be selectable by name", func() {
+			theme, err := ThemeByName("custom")
+			So(err, ShouldBeNil)
+			So(theme.Info([]byte("x")), ShouldResemble, Blue([]byte("x")))
+		})
+	})
+}
+
+func TestSequence(t *testing.T) {
+	Convey("Given colors and styles", t, func() {
+		Convey("It should return the sequence without the reset", func() {
+			So(Sequence(Red), ShouldResemble, colorRed)
+			So(string(Sequence(Style{Bold: true, FG: Cyan}.Color())), ShouldEqual, "\033[0;1;36m")
+			So(Sequence(nil), ShouldBeNil)
+		})
+	})
+}
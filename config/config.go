@@ -1,18 +1,81 @@
 package config
 
 import (
+	"os"
+
+	"github.com/rish1988/go-log/clock"
 	"github.com/rish1988/go-log/colorful"
+	"github.com/rish1988/go-log/cronjob"
 )
 
 type LogOptions struct {
 	ColorOptions
 	*FileOptions
 	Debug bool
+	// Least severe level to output: fatal, error, warn, info, debug or
+	// trace. Defaults to trace when Debug is set and info otherwise.
+	Level string
+	// Output format, either "text" (the default) or "json" for one JSON
+	// object per line
+	Format string
+	// Layout of text lines, e.g. "%{time:15:04:05} %{level:-5} %{message}".
+	// See the layout package for the placeholders. Defaults to prefix,
+	// timestamp, caller and message.
+	Layout string
+	// Format of the timestamp: a Go time layout, "rfc3339", "iso8601" or
+	// the Unix epoch formats "unix", "unixmilli", "unixmicro" and
+	// "unixnano". Defaults to the date format followed by the time of day.
+	TimeFormat string
+	// Sub-second precision of the default and preset time formats: "s",
+	// "ms", "us" or "ns"
+	TimePrecision string
+	// Print timestamps in UTC instead of the file options timezone
+	UTC bool
+	// Format of the caller info: "full" (function:file:line, the default),
+	// "short" (file:line), "relative" (path relative to the module
+	// root:line), "long" (full path:line), "func" (bare function name, e.g.
+	// "(*T).Method") or "package" (package.function)
+	CallerFormat string
+	// Stack traces attached to severe records, none when nil
+	Stack *StackOptions
+	// Time source for timestamps, log file names and rotation. Defaults to
+	// the system clock.
+	Clock clock.Clock
+	// Prefix overrides keyed by level name, e.g. "warn"
+	Prefixes map[string]PrefixOptions
+}
+
+// StackOptions attach stack traces to records at or above a level
+type StackOptions struct {
+	// Least severe level to attach stack traces to, e.g. "error"
+	Level string
+	// Drop frames of the Go runtime and standard library
+	HideRuntime bool
+	// Drop frames of packages matching these import path prefixes, e.g.
+	// "github.com/lib/pq"
+	HidePackages []string
+}
+
+// PrefixOptions override the prefix printed in front of a level
+type PrefixOptions struct {
+	// Text of the prefix including its trailing separator, e.g. "[WARN] ".
+	// Keeps the default text when empty.
+	Text string
+	// Color of the prefix, the level color when nil
+	Color colorful.Color
+	// Whether to print the caller info, keeps the level default when nil
+	Caller *bool
 }
 
 type ColorOptions struct {
+	// Force colored output on every writer instead of deciding per writer
 	Color bool
-	Quiet bool
+	// Disable colored output on every writer, wins over Color
+	NoColor bool
+	Quiet   bool
+	// Name of the color theme, see colorful.ThemeByName. The level colors
+	// below and the timestamp color win over the theme.
+	Theme string
 	TimeStampColorOptions
 	Info  colorful.Color
 	Warn  colorful.Color
@@ -27,6 +90,22 @@ type FileOptions struct {
 	FileName   string
 	DateFormat string
 	LogsDir    string
+	// Template for log file names, e.g. "{name}-{time:2006-01-02T15}-{host}-{pid}.log".
+	// Defaults to "{name}-{time}.log" where {time} is rendered with DateFormat.
+	FileNameTemplate string
+	// Name of a symlink in LogsDir that always points at the active log
+	// file. Leave empty to disable.
+	LinkName string
+	// Create LogsDir and any missing parents with DirMode instead of
+	// failing when it does not exist
+	CreateDirs bool
+	// Permissions for created directories, 0755 when zero
+	DirMode os.FileMode
+	// Permissions for log files, 0600 when zero
+	FileMode os.FileMode
+	// Group name or id to own the log files, so a log shipper running as
+	// another user can read them. Leave empty to keep the default group.
+	Group string
 	*RotationPolicyOptions
 }
 
@@ -34,6 +113,9 @@ type RotationPolicyOptions struct {
 	// Must be a valid cron expression
 	RotationInterval string
 	MaxFiles         int
+	// Scheduler shared with other loggers. When nil the logger runs its
+	// own scheduler in TimeZone driven by LogOptions.Clock.
+	Scheduler *cronjob.Scheduler
 }
 
 type TimeStampColorOptions struct {
@@ -0,0 +1,248 @@
+// Test file for config
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var samples = map[string]string{
+	"log.yaml": `
+level: warn
+timestamp: true
+colors:
+  info: cyan
+file:
+  dir: /var/log/app
+  name: app
+  file_mode: "0640"
+  rotation:
+    interval: "@hourly"
+    max_files: 24
+`,
+	"log.json": `{
+  "level": "warn",
+  "timestamp": true,
+  "colors": {"info": "cyan"},
+  "file": {
+    "dir": "/var/log/app",
+    "name": "app",
+    "file_mode": "0640",
+    "rotation": {"interval": "@hourly", "max_files": 24}
+  }
+}`,
+	"log.toml": `
+level = "warn"
+timestamp = true
+
+[colors]
+info = "cyan"
+
+[file]
+dir = "/var/log/app"
+name = "app"
+file_mode = "0640"
+
+[file.rotation]
+interval = "@hourly"
+max_files = 24
+`,
+}
+
+func TestLoad(t *testing.T) {
+	for name, content := range samples {
+		// A subtest per file, so the environment set by one is restored
+		// before the next
+		t.Run(name, func(t *testing.T) {
+			testLoadFile(t, name, content)
+		})
+	}
+
+	t.Run("theme", testLoadTheme)
+
+	Convey("Given a configuration with an unknown color", t, func() {
+		path := filepath.Join(t.TempDir(), "log.yaml")
+		So(os.WriteFile(path, []byte("colors:\n  info: rainbow\n"), 0600), ShouldBeNil)
+
+		Convey("It should fail to load", func() {
+			_, err := Load(path)
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	misspelled := map[string]string{
+		"log.yaml": "levle: warn\n",
+		"log.json": `{"levle": "warn"}`,
+		"log.toml": "levle = \"warn\"\n",
+	}
+	for name, content := range misspelled {
+		Convey("Given the configuration file "+name+" with a misspelled key", t, func() {
+			path := filepath.Join(t.TempDir(), name)
+			So(os.WriteFile(path, []byte(content), 0600), ShouldBeNil)
+
+			Convey("It should fail on the unknown key", func() {
+				_, err := Load(path)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "levle")
+			})
+		})
+	}
+
+	Convey("Given an empty YAML configuration", t, func() {
+		path := filepath.Join(t.TempDir(), "log.yaml")
+		So(os.WriteFile(path, nil, 0600), ShouldBeNil)
+
+		Convey("It should load the defaults", func() {
+			_, err := Load(path)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func testLoadFile(t *testing.T, name, content string) {
+	Convey("Given the configuration file "+name, t, func() {
+		path := filepath.Join(t.TempDir(), name)
+		So(os.WriteFile(path, []byte(content), 0600), ShouldBeNil)
+
+		Convey("When it is loaded", func() {
+			opts, err := Load(path)
+			So(err, ShouldBeNil)
+
+			Convey("It should fill in the options", func() {
+				So(opts.Level, ShouldEqual, "warn")
+				So(opts.TimeStamp, ShouldBeTrue)
+				So(opts.ColorOptions.Info, ShouldNotBeNil)
+				So(opts.LogsDir, ShouldEqual, "/var/log/app")
+				So(opts.FileName, ShouldEqual, "app")
+				So(opts.FileMode, ShouldEqual, os.FileMode(0640))
+				So(opts.RotationInterval, ShouldEqual, "@hourly")
+				So(opts.MaxFiles, ShouldEqual, 24)
+			})
+		})
+
+		Convey("When environment overrides are set", func() {
+			t.Setenv("GOLOG_LEVEL", "debug")
+			t.Setenv("GOLOG_FILE_DIR", "/tmp/logs")
+			t.Setenv("GOLOG_ROTATION_MAX_FILES", "7")
+
+			opts, err := Load(path)
+			So(err, ShouldBeNil)
+
+			Convey("It should prefer the environment", func() {
+				So(opts.Level, ShouldEqual, "debug")
+				So(opts.LogsDir, ShouldEqual, "/tmp/logs")
+				So(opts.MaxFiles, ShouldEqual, 7)
+			})
+		})
+	})
+}
+
+func testLoadTheme(t *testing.T) {
+	Convey("Given a configuration selecting a theme", t, func() {
+		path := filepath.Join(t.TempDir(), "log.yaml")
+		So(os.WriteFile(path, []byte("theme: solarized\n"), 0600), ShouldBeNil)
+
+		Convey("It should load the theme name", func() {
+			opts, err := Load(path)
+			So(err, ShouldBeNil)
+			So(opts.Theme, ShouldEqual, "solarized")
+		})
+
+		Convey("When the environment selects another theme", func() {
+			t.Setenv("GOLOG_THEME", "neon")
+
+			Convey("It should fail on the unknown theme", func() {
+				_, err := Load(path)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestValidate(t *testing.T) {
+	Convey("Given options with several invalid fields", t, func() {
+		opts := LogOptions{
+			Level:         "verbose",
+			TimePrecision: "cs",
+			ColorOptions:  ColorOptions{Theme: "neon"},
+			FileOptions: &FileOptions{
+				TimeZone: "Mars/Olympus_Mons",
+				LogsDir:  filepath.Join(t.TempDir(), "missing"),
+				RotationPolicyOptions: &RotationPolicyOptions{
+					RotationInterval: "every hour",
+					MaxFiles:         3,
+				},
+			},
+		}
+
+		Convey("When validated", func() {
+			err := opts.Validate()
+
+			Convey("It should report every invalid field", func() {
+				So(err, ShouldNotBeNil)
+				fields := map[string]bool{}
+				for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+					var fe *FieldError
+					So(errors.As(e, &fe), ShouldBeTrue)
+					fields[fe.Field] = true
+				}
+				So(fields, ShouldResemble, map[string]bool{
+					"Level":                                  true,
+					"TimePrecision":                          true,
+					"Theme":                                  true,
+					"FileOptions.TimeZone":                   true,
+					"FileOptions.LogsDir":                    true,
+					"RotationPolicyOptions.RotationInterval": true,
+				})
+			})
+		})
+	})
+
+	Convey("Given valid file options", t, func() {
+		opts := LogOptions{
+			Level:       "info",
+			FileOptions: &FileOptions{TimeZone: "UTC", LogsDir: t.TempDir(), FileName: "app"},
+		}
+
+		Convey("It should pass validation", func() {
+			So(opts.Validate(), ShouldBeNil)
+		})
+	})
+}
+
+func TestDiff(t *testing.T) {
+	Convey("Given two configuration files", t, func() {
+		caller := true
+		old := File{
+			Level:    "info",
+			Colors:   ColorNames{Info: "#ff0000"},
+			Prefixes: map[string]PrefixConfig{"warn": {Color: "red", Caller: &caller}},
+		}
+		next := old
+		next.Colors.Info = "#00ff00"
+		next.Prefixes = map[string]PrefixConfig{"warn": {Color: "red", Caller: new(bool)}}
+		*next.Prefixes["warn"].Caller = true
+		next.File = &FileConfig{Dir: "/var/log/app"}
+
+		Convey("It should list the changed settings only", func() {
+			So(old.Diff(next), ShouldResemble, []string{
+				"Colors.Info: #ff0000 -> #00ff00",
+				"File: unset -> set",
+			})
+		})
+
+		Convey("It should report added and removed prefixes", func() {
+			next = old
+			next.Prefixes = map[string]PrefixConfig{"error": {Text: "E"}}
+			So(old.Diff(next), ShouldResemble, []string{
+				"Prefixes.error: unset -> set",
+				"Prefixes.warn: set -> unset",
+			})
+		})
+	})
+}
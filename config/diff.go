@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Diff lists the settings that differ between f and next, one
+// "Field: old -> new" entry per setting. Colors are compared by the names
+// they are given in the file.
+func (f File) Diff(next File) []string {
+	var changes []string
+	diff("", reflect.ValueOf(f), reflect.ValueOf(next), &changes)
+	return changes
+}
+
+func diff(path string, a, b reflect.Value, changes *[]string) {
+	switch a.Kind() {
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if len(path) != 0 {
+				name = path + "." + name
+			}
+			diff(name, a.Field(i), b.Field(i), changes)
+		}
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				*changes = append(*changes, fmt.Sprintf("%s: %s -> %s", path, describe(a), describe(b)))
+			}
+			return
+		}
+		diff(path, a.Elem(), b.Elem(), changes)
+	case reflect.Map:
+		keys := map[string]reflect.Value{}
+		for _, v := range [...]reflect.Value{a, b} {
+			for _, key := range v.MapKeys() {
+				keys[fmt.Sprint(key.Interface())] = key
+			}
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			x, y := a.MapIndex(keys[name]), b.MapIndex(keys[name])
+			if !x.IsValid() || !y.IsValid() {
+				*changes = append(*changes, fmt.Sprintf("%s.%s: %s -> %s", path, name, present(x), present(y)))
+				continue
+			}
+			diff(path+"."+name, x, y, changes)
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*changes = append(*changes, fmt.Sprintf("%s: %v -> %v", path, a.Interface(), b.Interface()))
+		}
+	}
+}
+
+func present(v reflect.Value) string {
+	if !v.IsValid() {
+		return "unset"
+	}
+	return "set"
+}
+
+func describe(v reflect.Value) string {
+	if v.IsNil() {
+		return "unset"
+	}
+	return "set"
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// levelNames are the level names accepted in configuration, ordered from
+// most to least severe
+var levelNames = []string{"fatal", "error", "warn", "info", "debug", "trace"}
+
+// ParseLevel returns the severity of a level name, 0 being fatal and 5 being
+// trace
+func ParseLevel(name string) (int, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "warning" {
+		name = "warn"
+	}
+	for i, n := range levelNames {
+		if n == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown level [ %s ], expected one of %s", name, strings.Join(levelNames, ", "))
+}
+
+// LevelName returns the configuration name of a level severity
+func LevelName(level int) string {
+	if level < 0 || level >= len(levelNames) {
+		return fmt.Sprintf("level(%d)", level)
+	}
+	return levelNames[level]
+}
@@ -0,0 +1,359 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/rish1988/go-log/colorful"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes the environment variables overriding loaded options
+const EnvPrefix = "GOLOG_"
+
+// File is the serialized form of LogOptions. Colors are given by name, since
+// colorful.Color functions cannot be unmarshaled, and file modes as octal
+// strings such as "0640".
+type File struct {
+	Level     string                  `json:"level" yaml:"level" toml:"level"`
+	Format    string                  `json:"format" yaml:"format" toml:"format"`
+	Layout    string                  `json:"layout" yaml:"layout" toml:"layout"`
+	Time      TimeConfig              `json:"time" yaml:"time" toml:"time"`
+	Caller    string                  `json:"caller" yaml:"caller" toml:"caller"`
+	Stack     *StackConfig            `json:"stack" yaml:"stack" toml:"stack"`
+	Quiet     bool                    `json:"quiet" yaml:"quiet" toml:"quiet"`
+	Color     string                  `json:"color" yaml:"color" toml:"color"`
+	Theme     string                  `json:"theme" yaml:"theme" toml:"theme"`
+	TimeStamp bool                    `json:"timestamp" yaml:"timestamp" toml:"timestamp"`
+	Colors    ColorNames              `json:"colors" yaml:"colors" toml:"colors"`
+	Prefixes  map[string]PrefixConfig `json:"prefixes" yaml:"prefixes" toml:"prefixes"`
+	File      *FileConfig             `json:"file" yaml:"file" toml:"file"`
+}
+
+// TimeConfig is the serialized form of the timestamp settings
+type TimeConfig struct {
+	Format    string `json:"format" yaml:"format" toml:"format"`
+	Precision string `json:"precision" yaml:"precision" toml:"precision"`
+	UTC       bool   `json:"utc" yaml:"utc" toml:"utc"`
+}
+
+// StackConfig is the serialized form of StackOptions
+type StackConfig struct {
+	Level        string   `json:"level" yaml:"level" toml:"level"`
+	HideRuntime  bool     `json:"hide_runtime" yaml:"hide_runtime" toml:"hide_runtime"`
+	HidePackages []string `json:"hide_packages" yaml:"hide_packages" toml:"hide_packages"`
+}
+
+// ColorNames name the color of each level
+type ColorNames struct {
+	Info  string `json:"info" yaml:"info" toml:"info"`
+	Warn  string `json:"warn" yaml:"warn" toml:"warn"`
+	Debug string `json:"debug" yaml:"debug" toml:"debug"`
+	Trace string `json:"trace" yaml:"trace" toml:"trace"`
+	Fatal string `json:"fatal" yaml:"fatal" toml:"fatal"`
+	Error string `json:"error" yaml:"error" toml:"error"`
+}
+
+// PrefixConfig is the serialized form of PrefixOptions
+type PrefixConfig struct {
+	Text   string `json:"text" yaml:"text" toml:"text"`
+	Color  string `json:"color" yaml:"color" toml:"color"`
+	Caller *bool  `json:"caller" yaml:"caller" toml:"caller"`
+}
+
+// FileConfig is the serialized form of FileOptions
+type FileConfig struct {
+	Dir          string          `json:"dir" yaml:"dir" toml:"dir"`
+	Name         string          `json:"name" yaml:"name" toml:"name"`
+	NameTemplate string          `json:"name_template" yaml:"name_template" toml:"name_template"`
+	LinkName     string          `json:"link_name" yaml:"link_name" toml:"link_name"`
+	TimeZone     string          `json:"timezone" yaml:"timezone" toml:"timezone"`
+	DateFormat   string          `json:"date_format" yaml:"date_format" toml:"date_format"`
+	CreateDirs   bool            `json:"create_dirs" yaml:"create_dirs" toml:"create_dirs"`
+	DirMode      string          `json:"dir_mode" yaml:"dir_mode" toml:"dir_mode"`
+	FileMode     string          `json:"file_mode" yaml:"file_mode" toml:"file_mode"`
+	Group        string          `json:"group" yaml:"group" toml:"group"`
+	Rotation     *RotationConfig `json:"rotation" yaml:"rotation" toml:"rotation"`
+}
+
+// RotationConfig is the serialized form of RotationPolicyOptions
+type RotationConfig struct {
+	Interval string `json:"interval" yaml:"interval" toml:"interval"`
+	MaxFiles int    `json:"max_files" yaml:"max_files" toml:"max_files"`
+}
+
+// Load reads LogOptions from a YAML, JSON or TOML file, picked by the file
+// extension, and applies the GOLOG_* environment overrides on top
+func Load(path string) (LogOptions, error) {
+	f, err := LoadFile(path)
+	if err != nil {
+		return LogOptions{}, err
+	}
+	return f.Options()
+}
+
+// LoadFile reads the serialized configuration of Load, with the environment
+// overrides applied, without turning it into LogOptions
+func LoadFile(path string) (File, error) {
+	var f File
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return f, fmt.Errorf("failed to read log configuration [ %s ]. Reason: %s", path, err)
+	}
+
+	if err = Unmarshal(data, filepath.Ext(path), &f); err != nil {
+		return f, fmt.Errorf("failed to parse log configuration [ %s ]. Reason: %s", path, err)
+	}
+
+	if err = f.ApplyEnv(); err != nil {
+		return f, err
+	}
+	return f, nil
+}
+
+// LoadEnv builds LogOptions from the GOLOG_* environment variables only
+func LoadEnv() (LogOptions, error) {
+	var f File
+	if err := f.ApplyEnv(); err != nil {
+		return LogOptions{}, err
+	}
+	return f.Options()
+}
+
+// Unmarshal decodes data in the format named by ext, e.g. ".yaml", ".yml",
+// ".json" or ".toml". Unknown keys are rejected in every format.
+func Unmarshal(data []byte, ext string, f *File) error {
+	switch strings.ToLower(strings.TrimPrefix(ext, ".")) {
+	case "yaml", "yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(f); err != nil && err != io.EOF {
+			return err
+		}
+		return nil
+	case "json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(f)
+	case "toml":
+		md, err := toml.Decode(string(data), f)
+		if err != nil {
+			return err
+		}
+		if undecoded := md.Undecoded(); len(undecoded) != 0 {
+			return fmt.Errorf("unknown field [ %s ]", undecoded[0])
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported configuration format [ %s ]", ext)
+}
+
+// ApplyEnv overrides the file settings with the GOLOG_* environment
+// variables that are set
+func (f *File) ApplyEnv() error {
+	var err error
+
+	env(&err, "LEVEL", stringVar(&f.Level))
+	env(&err, "FORMAT", stringVar(&f.Format))
+	env(&err, "LAYOUT", stringVar(&f.Layout))
+	env(&err, "QUIET", boolVar(&f.Quiet))
+	env(&err, "COLOR", stringVar(&f.Color))
+	env(&err, "THEME", stringVar(&f.Theme))
+	env(&err, "TIMESTAMP", boolVar(&f.TimeStamp))
+	env(&err, "TIME_FORMAT", stringVar(&f.Time.Format))
+	env(&err, "TIME_PRECISION", stringVar(&f.Time.Precision))
+	env(&err, "TIME_UTC", boolVar(&f.Time.UTC))
+	env(&err, "CALLER", stringVar(&f.Caller))
+	env(&err, "STACK_LEVEL", func(v string) error {
+		if f.Stack == nil {
+			f.Stack = &StackConfig{}
+		}
+		return stringVar(&f.Stack.Level)(v)
+	})
+
+	env(&err, "COLOR_INFO", stringVar(&f.Colors.Info))
+	env(&err, "COLOR_WARN", stringVar(&f.Colors.Warn))
+	env(&err, "COLOR_DEBUG", stringVar(&f.Colors.Debug))
+	env(&err, "COLOR_TRACE", stringVar(&f.Colors.Trace))
+	env(&err, "COLOR_FATAL", stringVar(&f.Colors.Fatal))
+	env(&err, "COLOR_ERROR", stringVar(&f.Colors.Error))
+
+	file := func() *FileConfig {
+		if f.File == nil {
+			f.File = &FileConfig{}
+		}
+		return f.File
+	}
+	rotation := func() *RotationConfig {
+		if file().Rotation == nil {
+			f.File.Rotation = &RotationConfig{}
+		}
+		return f.File.Rotation
+	}
+
+	env(&err, "FILE_DIR", func(v string) error { return stringVar(&file().Dir)(v) })
+	env(&err, "FILE_NAME", func(v string) error { return stringVar(&file().Name)(v) })
+	env(&err, "FILE_TIMEZONE", func(v string) error { return stringVar(&file().TimeZone)(v) })
+	env(&err, "ROTATION_INTERVAL", func(v string) error { return stringVar(&rotation().Interval)(v) })
+	env(&err, "ROTATION_MAX_FILES", func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err == nil {
+			rotation().MaxFiles = n
+		}
+		return err
+	})
+	return err
+}
+
+// Options converts the serialized settings to LogOptions
+func (f *File) Options() (LogOptions, error) {
+	var (
+		opts LogOptions
+		err  error
+	)
+
+	if len(f.Level) != 0 {
+		if _, err = ParseLevel(f.Level); err != nil {
+			return opts, err
+		}
+	}
+	opts.Level = f.Level
+	opts.Format = f.Format
+	opts.Layout = f.Layout
+	opts.Quiet = f.Quiet
+	switch strings.ToLower(f.Color) {
+	case "", "auto":
+	case "always":
+		opts.Color = true
+	case "never":
+		opts.NoColor = true
+	default:
+		return opts, fmt.Errorf("invalid color mode [ %s ], expected auto, always or never", f.Color)
+	}
+	if len(f.Theme) != 0 {
+		if _, err = colorful.ThemeByName(f.Theme); err != nil {
+			return opts, err
+		}
+	}
+	opts.Theme = f.Theme
+	opts.TimeStamp = f.TimeStamp
+	opts.TimeFormat = f.Time.Format
+	opts.TimePrecision = f.Time.Precision
+	opts.UTC = f.Time.UTC
+	opts.CallerFormat = f.Caller
+	if f.Stack != nil {
+		opts.Stack = &StackOptions{
+			Level:        f.Stack.Level,
+			HideRuntime:  f.Stack.HideRuntime,
+			HidePackages: f.Stack.HidePackages,
+		}
+	}
+
+	for _, c := range []struct {
+		name  string
+		color *colorful.Color
+	}{
+		{f.Colors.Info, &opts.ColorOptions.Info},
+		{f.Colors.Warn, &opts.ColorOptions.Warn},
+		{f.Colors.Debug, &opts.ColorOptions.Debug},
+		{f.Colors.Trace, &opts.ColorOptions.Trace},
+		{f.Colors.Fatal, &opts.ColorOptions.Fatal},
+		{f.Colors.Error, &opts.ColorOptions.Error},
+	} {
+		if len(c.name) == 0 {
+			continue
+		}
+		if *c.color, err = colorful.ByName(c.name); err != nil {
+			return opts, err
+		}
+	}
+
+	for level, p := range f.Prefixes {
+		if opts.Prefixes == nil {
+			opts.Prefixes = make(map[string]PrefixOptions, len(f.Prefixes))
+		}
+		prefix := PrefixOptions{Text: p.Text, Caller: p.Caller}
+		if len(p.Color) != 0 {
+			if prefix.Color, err = colorful.ByName(p.Color); err != nil {
+				return opts, err
+			}
+		}
+		opts.Prefixes[level] = prefix
+	}
+
+	if f.File == nil {
+		return opts, nil
+	}
+
+	opts.FileOptions = &FileOptions{
+		TimeZone:         f.File.TimeZone,
+		FileName:         f.File.Name,
+		DateFormat:       f.File.DateFormat,
+		LogsDir:          f.File.Dir,
+		FileNameTemplate: f.File.NameTemplate,
+		LinkName:         f.File.LinkName,
+		CreateDirs:       f.File.CreateDirs,
+		Group:            f.File.Group,
+	}
+	if opts.DirMode, err = fileMode(f.File.DirMode); err != nil {
+		return opts, err
+	}
+	if opts.FileMode, err = fileMode(f.File.FileMode); err != nil {
+		return opts, err
+	}
+
+	if f.File.Rotation != nil {
+		opts.RotationPolicyOptions = &RotationPolicyOptions{
+			RotationInterval: f.File.Rotation.Interval,
+			MaxFiles:         f.File.Rotation.MaxFiles,
+		}
+	}
+	return opts, nil
+}
+
+func fileMode(mode string) (os.FileMode, error) {
+	if len(mode) == 0 {
+		return 0, nil
+	}
+	m, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid file mode [ %s ], expected octal such as 0640", mode)
+	}
+	return os.FileMode(m), nil
+}
+
+// env calls set with the value of the GOLOG_<name> variable when it is set,
+// keeping the first error in err
+func env(err *error, name string, set func(string) error) {
+	v, ok := os.LookupEnv(EnvPrefix + name)
+	if !ok || *err != nil {
+		return
+	}
+	if e := set(v); e != nil {
+		*err = fmt.Errorf("invalid %s%s [ %s ]. Reason: %s", EnvPrefix, name, v, e)
+	}
+}
+
+func stringVar(dst *string) func(string) error {
+	return func(v string) error {
+		*dst = v
+		return nil
+	}
+}
+
+func boolVar(dst *bool) func(string) error {
+	return func(v string) error {
+		b, err := strconv.ParseBool(v)
+		if err == nil {
+			*dst = b
+		}
+		return err
+	}
+}
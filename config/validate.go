@@ -0,0 +1,166 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rish1988/go-log/clock"
+	"github.com/rish1988/go-log/colorful"
+	"github.com/rish1988/go-log/files"
+	"github.com/rish1988/go-log/layout"
+	"github.com/robfig/cron"
+)
+
+// FieldError reports an invalid LogOptions field
+type FieldError struct {
+	// Field is the path of the field, e.g. "FileOptions.TimeZone"
+	Field string
+	Value interface{}
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid %s [ %v ]. Reason: %s", e.Field, e.Value, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks the options and returns every problem found as a
+// *FieldError joined with errors.Join, or nil when the options are usable
+func (o LogOptions) Validate() error {
+	var errs []error
+	invalid := func(field string, value interface{}, err error) {
+		errs = append(errs, &FieldError{Field: field, Value: value, Err: err})
+	}
+
+	if len(o.Level) != 0 {
+		if _, err := ParseLevel(o.Level); err != nil {
+			invalid("Level", o.Level, err)
+		}
+	}
+
+	if o.Stack != nil {
+		if _, err := ParseLevel(o.Stack.Level); err != nil {
+			invalid("Stack.Level", o.Stack.Level, err)
+		}
+	}
+
+	for name := range o.Prefixes {
+		if _, err := ParseLevel(name); err != nil {
+			invalid("Prefixes", name, err)
+		}
+	}
+
+	switch o.Format {
+	case "", "text", "json":
+	default:
+		invalid("Format", o.Format, errors.New(`expected "text" or "json"`))
+	}
+
+	switch o.TimePrecision {
+	case "", "s", "ms", "us", "µs", "ns":
+	default:
+		invalid("TimePrecision", o.TimePrecision, errors.New(`expected "s", "ms", "us" or "ns"`))
+	}
+
+	switch o.CallerFormat {
+	case "", "full", "short", "relative", "long", "func", "package":
+	default:
+		invalid("CallerFormat", o.CallerFormat, errors.New(`expected "full", "short", "relative", "long", "func" or "package"`))
+	}
+
+	if len(o.ColorOptions.Theme) != 0 {
+		if _, err := colorful.ThemeByName(o.ColorOptions.Theme); err != nil {
+			invalid("Theme", o.ColorOptions.Theme, err)
+		}
+	}
+
+	if len(o.Layout) != 0 {
+		if _, err := layout.Parse(o.Layout); err != nil {
+			invalid("Layout", o.Layout, err)
+		}
+	}
+
+	if o.FileOptions == nil {
+		return errors.Join(errs...)
+	}
+
+	// Rotation is scheduled in the file timezone, the local one by default
+	location := time.Local
+	if len(o.TimeZone) != 0 {
+		if loaded, err := time.LoadLocation(o.TimeZone); err != nil {
+			invalid("FileOptions.TimeZone", o.TimeZone, err)
+		} else {
+			location = loaded
+		}
+	}
+
+	if len(o.LogsDir) == 0 {
+		invalid("FileOptions.LogsDir", o.LogsDir, errors.New("no log directory configured"))
+	} else if err := checkDir(o.LogsDir, o.CreateDirs); err != nil {
+		invalid("FileOptions.LogsDir", o.LogsDir, err)
+	}
+
+	if len(o.Group) != 0 {
+		if _, err := files.LookupGroup(o.Group); err != nil {
+			invalid("FileOptions.Group", o.Group, err)
+		}
+	}
+
+	dateFormat := o.DateFormat
+	if len(dateFormat) == 0 {
+		dateFormat = "02-Jan-2006"
+	}
+	tmpl, err := files.ParseTemplate(o.FileNameTemplate, dateFormat)
+	if err != nil {
+		invalid("FileOptions.FileNameTemplate", o.FileNameTemplate, err)
+	}
+
+	interval := "@midnight"
+	if o.RotationPolicyOptions != nil {
+		interval = o.RotationInterval
+		if _, err := cron.Parse(interval); err != nil {
+			invalid("RotationPolicyOptions.RotationInterval", interval, err)
+			interval = ""
+		}
+		if o.MaxFiles <= 0 {
+			invalid("RotationPolicyOptions.MaxFiles", o.MaxFiles, errors.New("must keep at least one file"))
+		}
+	}
+
+	if tmpl != nil && len(interval) != 0 {
+		if err := tmpl.CheckSchedule(interval, clock.OrSystem(o.Clock).Now().In(location)); err != nil {
+			invalid("FileOptions.FileNameTemplate", tmpl, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkDir verifies that log files can be created in dir, or that dir can be
+// created when create is set
+func checkDir(dir string, create bool) error {
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		if create {
+			return nil
+		}
+		return errors.New("directory does not exist")
+	} else if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return errors.New("not a directory")
+	}
+
+	probe, err := os.CreateTemp(dir, ".golog-*")
+	if err != nil {
+		return fmt.Errorf("directory is not writable: %s", err)
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
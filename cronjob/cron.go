@@ -2,12 +2,36 @@ package cronjob
 
 import (
 	"fmt"
-	"github.com/robfig/cron"
+	"sort"
+	"sync"
 	"time"
+
+	"github.com/rish1988/go-log/clock"
+	"github.com/robfig/cron"
 )
 
+// Scheduler runs the rotation jobs of one or more loggers. Jobs can be
+// removed again so a stopped logger no longer rotates while other loggers
+// sharing the scheduler keep going. Time is taken from a clock.Clock so
+// rotation can be driven by a fake clock in tests.
+type Scheduler struct {
+	mu       sync.Mutex
+	location *time.Location
+	clock    clock.Clock
+	entries  map[int]*entry
+	nextID   int
+	stop     chan struct{}
+	wake     chan struct{}
+}
+
+// c is the scheduler shared by every caller of NewCron
 var c *cron.Cron
 
+// NewCron returns the process wide cron scheduler, created in timeZone by the
+// first call; later calls return the same scheduler whatever their timezone.
+//
+// Deprecated: loggers no longer share this scheduler, use NewScheduler to get
+// a scheduler of your own.
 func NewCron(timeZone string) *cron.Cron {
 	if c == nil {
 		if currentZone, err := time.LoadLocation(timeZone); err != nil {
@@ -19,3 +43,197 @@ func NewCron(timeZone string) *cron.Cron {
 	}
 	return c
 }
+
+type entry struct {
+	schedule cron.Schedule
+	cmd      cron.Job
+	next     time.Time
+}
+
+// NewScheduler returns a stopped scheduler running its jobs in timeZone,
+// falling back to the local timezone when it cannot be loaded
+func NewScheduler(timeZone string) *Scheduler {
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		fmt.Printf("Failed to load %s timezone. Reason: %s", timeZone, err)
+		location = time.Local
+	}
+	return NewSchedulerWithLocation(location)
+}
+
+// NewSchedulerWithLocation returns a stopped scheduler running its jobs in
+// location, or in the local timezone when location is nil
+func NewSchedulerWithLocation(location *time.Location) *Scheduler {
+	return NewSchedulerWithClock(location, clock.System)
+}
+
+// NewSchedulerWithClock returns a stopped scheduler running its jobs in
+// location according to the time reported by clk
+func NewSchedulerWithClock(location *time.Location, clk clock.Clock) *Scheduler {
+	if location == nil {
+		location = time.Local
+	}
+	return &Scheduler{
+		location: location,
+		clock:    clock.OrSystem(clk),
+		entries:  make(map[int]*entry),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Location returns the timezone the schedules are evaluated in
+func (s *Scheduler) Location() *time.Location {
+	return s.location
+}
+
+// AddJob schedules cmd according to the cron spec and returns an id that can
+// be passed to Remove
+func (s *Scheduler) AddJob(spec string, cmd cron.Job) (int, error) {
+	schedule, err := cron.Parse(spec)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	s.entries[s.nextID] = &entry{
+		schedule: schedule,
+		cmd:      cmd,
+		next:     schedule.Next(s.now()),
+	}
+	s.notify()
+	return s.nextID, nil
+}
+
+// Remove unschedules the jobs with the given ids
+func (s *Scheduler) Remove(ids ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		delete(s.entries, id)
+	}
+	s.notify()
+}
+
+// Start runs the scheduler in its own goroutine. Starting a running
+// scheduler is a no-op.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+
+	now := s.now()
+	for _, e := range s.entries {
+		e.next = e.schedule.Next(now)
+	}
+	s.stop = make(chan struct{})
+	go s.run(s.stop)
+}
+
+// Stop halts the scheduler without removing its jobs
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+// Len returns the number of scheduled jobs
+func (s *Scheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+func (s *Scheduler) now() time.Time {
+	return s.clock.Now().In(s.location)
+}
+
+// notify wakes up the run loop so it picks up changed entries
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) run(stop chan struct{}) {
+	for {
+		s.mu.Lock()
+		var next time.Time
+		for _, e := range s.entries {
+			if next.IsZero() || e.next.Before(next) {
+				next = e.next
+			}
+		}
+		now := s.now()
+		s.mu.Unlock()
+
+		var (
+			timer clock.Timer
+			fire  <-chan time.Time
+		)
+		if !next.IsZero() {
+			timer = s.clock.NewTimer(next.Sub(now))
+			fire = timer.C()
+		}
+
+		select {
+		case <-fire:
+			s.runDue()
+		case <-s.wake:
+		case <-stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// runDue runs the jobs whose time has come, one after another so a slow
+// rotation never overlaps with the next one
+func (s *Scheduler) runDue() {
+	s.mu.Lock()
+	now := s.now()
+	var ids []int
+	for id, e := range s.entries {
+		if !e.next.After(now) {
+			ids = append(ids, id)
+			e.next = e.schedule.Next(now)
+		}
+	}
+	// Run in the order the jobs were added, e.g. rotate before cleanup
+	sort.Ints(ids)
+	due := make([]cron.Job, 0, len(ids))
+	for _, id := range ids {
+		due = append(due, s.entries[id].cmd)
+	}
+	s.mu.Unlock()
+
+	for _, cmd := range due {
+		runWithRecovery(cmd)
+	}
+}
+
+func runWithRecovery(cmd cron.Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("Logger cronjob panicked. Reason: %v\n", r)
+		}
+	}()
+	cmd.Run()
+}
@@ -0,0 +1,87 @@
+// Test file for cronjob
+
+package cronjob
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rish1988/go-log/clock/clocktest"
+	"github.com/robfig/cron"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSchedulerRemove(t *testing.T) {
+	Convey("Given a running scheduler shared by two owners", t, func() {
+		s := NewSchedulerWithLocation(time.UTC)
+		noop := cron.FuncJob(func() {})
+
+		first, err := s.AddJob("@midnight", noop)
+		So(err, ShouldBeNil)
+		second, err := s.AddJob("@hourly", noop)
+		So(err, ShouldBeNil)
+		s.Start()
+		defer s.Stop()
+
+		Convey("When the first owner removes its job", func() {
+			s.Remove(first)
+
+			Convey("It should keep the other job scheduled", func() {
+				So(s.Len(), ShouldEqual, 1)
+			})
+
+			Convey("It should drop the remaining job once removed as well", func() {
+				s.Remove(second)
+				So(s.Len(), ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given an invalid cron spec", t, func() {
+		s := NewSchedulerWithLocation(time.UTC)
+
+		Convey("It should fail to add the job", func() {
+			_, err := s.AddJob("every day", cron.FuncJob(func() {}))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestSchedulerFakeClock(t *testing.T) {
+	Convey("Given a scheduler driven by a fake clock", t, func() {
+		fake := clocktest.NewFake(time.Date(2026, time.October, 17, 23, 59, 0, 0, time.UTC))
+		s := NewSchedulerWithClock(time.UTC, fake)
+		runs := make(chan time.Time, 1)
+
+		_, err := s.AddJob("@midnight", cron.FuncJob(func() {
+			runs <- fake.Now()
+		}))
+		So(err, ShouldBeNil)
+		s.Start()
+		defer s.Stop()
+
+		Convey("When the clock is advanced past midnight", func() {
+			fake.BlockUntil(1)
+			fake.Advance(time.Minute)
+
+			Convey("It should run the job right away", func() {
+				select {
+				case at := <-runs:
+					So(at.Day(), ShouldEqual, 18)
+				case <-time.After(time.Second):
+					So("job did not run", ShouldBeEmpty)
+				}
+			})
+		})
+	})
+}
+
+func TestNewCron(t *testing.T) {
+	Convey("Given the deprecated process wide cron", t, func() {
+		first := NewCron("UTC")
+
+		Convey("It should be shared by every caller", func() {
+			So(NewCron("Asia/Jakarta"), ShouldPointTo, first)
+		})
+	})
+}
@@ -0,0 +1,150 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/rish1988/go-log/config"
+)
+
+// defaultLogger is the logger used by the package level functions
+var defaultLogger atomic.Pointer[Logger]
+
+func init() {
+	SetDefault(nil)
+}
+
+// Default returns the process wide default logger
+func Default() *Logger {
+	return defaultLogger.Load()
+}
+
+// SetDefault replace the default logger, safe while other goroutines are
+// logging. A nil logger restores the initial one writing to stderr.
+func SetDefault(l *Logger) {
+	if l == nil {
+		l = newLogger(NewFdWriters(os.Stderr), config.LogOptions{})
+	}
+	defaultLogger.Store(l)
+}
+
+// The package level functions below mirror the logger methods on the default
+// logger. Fatal, Error, Warn, Info, Debug and Trace name the levels already,
+// so the unformatted variants are spelled Fatalln, Errorln, ..., or
+// Log(level, ...).
+
+// Log print v at level to the default logger, and quit the application with
+// status 1 for Fatal
+func Log(level MessageType, v ...interface{}) {
+	l := Default()
+	if level == Fatal {
+		l.log(Fatal, fmt.Sprintln(v...), v)
+		os.Exit(1)
+	}
+	if l.enabled(level) {
+		l.log(level, fmt.Sprintln(v...), v)
+	}
+}
+
+// Logf print formatted v at level to the default logger, and quit the
+// application with status 1 for Fatal
+func Logf(level MessageType, format string, v ...interface{}) {
+	l := Default()
+	if level == Fatal {
+		l.log(Fatal, fmt.Sprintf(format, v...), v)
+		os.Exit(1)
+	}
+	if l.enabled(level) {
+		l.log(level, fmt.Sprintf(format, v...), v)
+	}
+}
+
+// Fatalf print formatted fatal message to the default logger and quit the
+// application with status 1
+func Fatalf(format string, v ...interface{}) {
+	l := Default()
+	l.log(Fatal, fmt.Sprintf(format, v...), v)
+	os.Exit(1)
+}
+
+// Errorf print formatted error message to the default logger
+func Errorf(format string, v ...interface{}) {
+	if l := Default(); l.enabled(Error) {
+		l.log(Error, fmt.Sprintf(format, v...), v)
+	}
+}
+
+// Warnf print formatted warning message to the default logger
+func Warnf(format string, v ...interface{}) {
+	if l := Default(); l.enabled(Warn) {
+		l.log(Warn, fmt.Sprintf(format, v...), v)
+	}
+}
+
+// Infof print formatted informational message to the default logger
+func Infof(format string, v ...interface{}) {
+	if l := Default(); l.enabled(Info) {
+		l.log(Info, fmt.Sprintf(format, v...), v)
+	}
+}
+
+// Debugf print formatted debug message to the default logger
+func Debugf(format string, v ...interface{}) {
+	if l := Default(); l.enabled(Debug) {
+		l.log(Debug, fmt.Sprintf(format, v...), v)
+	}
+}
+
+// Tracef print formatted trace message to the default logger
+func Tracef(format string, v ...interface{}) {
+	if l := Default(); l.enabled(Trace) {
+		l.log(Trace, fmt.Sprintf(format, v...), v)
+	}
+}
+
+// Fatalln print fatal message to the default logger and quit the application
+// with status 1
+func Fatalln(v ...interface{}) {
+	l := Default()
+	l.log(Fatal, fmt.Sprintln(v...), v)
+	os.Exit(1)
+}
+
+// Errorln print error message to the default logger
+func Errorln(v ...interface{}) {
+	if l := Default(); l.enabled(Error) {
+		l.log(Error, fmt.Sprintln(v...), v)
+	}
+}
+
+// Warnln print warning message to the default logger
+func Warnln(v ...interface{}) {
+	if l := Default(); l.enabled(Warn) {
+		l.log(Warn, fmt.Sprintln(v...), v)
+	}
+}
+
+// Infoln print informational message to the default logger
+func Infoln(v ...interface{}) {
+	if l := Default(); l.enabled(Info) {
+		l.log(Info, fmt.Sprintln(v...), v)
+	}
+}
+
+// Debugln print debug message to the default logger
+func Debugln(v ...interface{}) {
+	if l := Default(); l.enabled(Debug) {
+		l.log(Debug, fmt.Sprintln(v...), v)
+	}
+}
+
+// Traceln print trace message to the default logger
+func Traceln(v ...interface{}) {
+	if l := Default(); l.enabled(Trace) {
+		l.log(Trace, fmt.Sprintln(v...), v)
+	}
+}
@@ -0,0 +1,80 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the default logger
+
+package log_test
+
+import (
+	"sync"
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDefault(t *testing.T) {
+	Convey("Given a default logger with caller info on every level", t, func() {
+		out := &pipe{}
+		logger, err := log.NewLogger(
+			log.WithOutput(out),
+			log.WithCaller(log.Info, true),
+			log.WithCaller(log.Warn, true),
+			log.WithCallerFormat("short"),
+		)
+		So(err, ShouldBeNil)
+		previous := log.Default()
+		log.SetDefault(logger)
+		Reset(func() {
+			log.SetDefault(previous)
+		})
+
+		Convey("When the package level functions are used", func() {
+			log.Infof("started %d workers", 4)
+			log.Log(log.Warn, "queue full")
+			log.Debugf("dropped")
+			log.Errorln("disk", "full")
+			log.Infoln("stopped")
+			log.Traceln("dropped")
+
+			Convey("It should write to the default logger reporting their caller", func() {
+				So(out.String(), ShouldStartWith, "[INFO]  default_test.go:33 started 4 workers\n"+
+					"[WARN]  default_test.go:34 queue full\n")
+				So(out.String(), ShouldEndWith, " disk full\n[INFO]  default_test.go:37 stopped\n")
+			})
+		})
+
+		Convey("When the default logger is replaced while logging", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 100; j++ {
+						log.Infof("tick")
+					}
+				}()
+			}
+			for i := 0; i < 10; i++ {
+				log.SetDefault(log.New(log.NewFdWriters(&pipe{}), log.Default().Options()))
+			}
+			wg.Wait()
+
+			Convey("It should keep logging without races", func() {
+				So(log.Default(), ShouldNotPointTo, logger)
+			})
+		})
+	})
+
+	Convey("Given the default logger is reset", t, func() {
+		previous := log.Default()
+		log.SetDefault(nil)
+		Reset(func() {
+			log.SetDefault(previous)
+		})
+
+		Convey("It should restore a logger", func() {
+			So(log.Default(), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,165 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/rish1988/go-log/buffer"
+)
+
+// maxErrorDepth limits how deep error chains are unwrapped
+const maxErrorDepth = 16
+
+// ErrorInfo describes an error logged with a record and the errors it wraps
+type ErrorInfo struct {
+	// Type is the dynamic type of the error, e.g. "*fs.PathError"
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	// Fields exposed by the error through a LogFields method
+	Fields Fields `json:"fields,omitempty"`
+	// Stack carried by the error through a StackTrace method, like the
+	// errors of github.com/pkg/errors
+	Stack []Caller `json:"stack,omitempty"`
+	// Causes are the wrapped errors, more than one for errors.Join
+	Causes []ErrorInfo `json:"causes,omitempty"`
+}
+
+// FieldsError is implemented by errors exposing fields to log along with them
+type FieldsError interface {
+	error
+	LogFields() map[string]interface{}
+}
+
+// errorInfos describes the errors among args
+func errorInfos(args []interface{}) []ErrorInfo {
+	var infos []ErrorInfo
+	for _, arg := range args {
+		if err, ok := arg.(error); ok && err != nil {
+			infos = append(infos, describeError(err, 0))
+		}
+	}
+	return infos
+}
+
+// describeError describes err and, up to maxErrorDepth, the errors it wraps.
+// Like fmt, a nil pointer is described as <nil> and a panicking method as
+// <PANIC=...> instead of crashing the logger.
+func describeError(err error, depth int) (info ErrorInfo) {
+	info.Type = fmt.Sprintf("%T", err)
+	if v := reflect.ValueOf(err); v.Kind() == reflect.Ptr && v.IsNil() {
+		info.Message = "<nil>"
+		return info
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			info.Message = fmt.Sprintf("<PANIC=%v>", p)
+		}
+	}()
+
+	info.Message = err.Error()
+	info.Stack = errorStack(err)
+	if f, ok := err.(FieldsError); ok {
+		info.Fields = f.LogFields()
+	}
+	if depth == maxErrorDepth {
+		return info
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, cause := range joined.Unwrap() {
+			if cause != nil {
+				info.Causes = append(info.Causes, describeError(cause, depth+1))
+			}
+		}
+	} else if cause := errors.Unwrap(err); cause != nil {
+		info.Causes = []ErrorInfo{describeError(cause, depth+1)}
+	}
+	return info
+}
+
+// errorStack returns the stack of errors having a StackTrace method
+// returning program counters, which covers the Frame slices of
+// github.com/pkg/errors without depending on it
+func errorStack(err error) []Caller {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() {
+		return nil
+	}
+	typ := method.Type()
+	if typ.NumIn() != 0 || typ.NumOut() != 1 || typ.Out(0).Kind() != reflect.Slice || typ.Out(0).Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	trace := method.Call(nil)[0]
+	pcs := make([]uintptr, trace.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(trace.Index(i).Uint())
+	}
+
+	var stack []Caller
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		if f.PC != 0 {
+			stack = append(stack, Caller{Function: f.Function, File: f.File, Line: f.Line})
+		}
+		if !more {
+			return stack
+		}
+	}
+}
+
+// detailed check whether printing the error tree tells more than the
+// message already does
+func (e ErrorInfo) detailed() bool {
+	return len(e.Causes) != 0 || len(e.Fields) != 0 || len(e.Stack) != 0
+}
+
+// appendErrors append the errors with more details than their message as an
+// indented tree
+func appendErrors(buf *buffer.Buffer, infos []ErrorInfo) {
+	for _, info := range infos {
+		if info.detailed() {
+			appendErrorTree(buf, info, "    ", "    ")
+		}
+	}
+}
+
+// appendErrorTree append info on a line starting with first, and the lines
+// below it starting with indent
+func appendErrorTree(buf *buffer.Buffer, info ErrorInfo, first, indent string) {
+	buf.AppendString(first)
+	buf.AppendString(info.Type)
+	// The message of joined errors is the one of their causes
+	if len(info.Causes) < 2 {
+		buf.AppendString(": ")
+		buf.AppendString(strings.ReplaceAll(info.Message, "\n", "; "))
+	}
+	buf.AppendString(info.Fields.String())
+	buf.AppendByte('\n')
+
+	for _, c := range info.Stack {
+		buf.AppendString(indent)
+		buf.AppendString("  at ")
+		buf.AppendString(c.Function)
+		buf.AppendByte(' ')
+		buf.AppendString(c.File)
+		buf.AppendByte(':')
+		buf.AppendInt(c.Line, 0)
+		buf.AppendByte('\n')
+	}
+
+	for i, cause := range info.Causes {
+		if i == len(info.Causes)-1 {
+			appendErrorTree(buf, cause, indent+"└─ ", indent+"   ")
+		} else {
+			appendErrorTree(buf, cause, indent+"├─ ", indent+"│  ")
+		}
+	}
+}
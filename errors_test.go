@@ -0,0 +1,136 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the error rendering
+
+package log_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// frames mimics the stack trace type of github.com/pkg/errors
+type frames []uintptr
+
+// queryError exposes fields and a stack trace
+type queryError struct {
+	query string
+	stack frames
+}
+
+func newQueryError(query string) *queryError {
+	pcs := make([]uintptr, 1)
+	runtime.Callers(2, pcs)
+	return &queryError{query: query, stack: pcs}
+}
+
+func (e *queryError) Error() string {
+	return "query timed out"
+}
+
+func (e *queryError) LogFields() map[string]any {
+	return map[string]any{"query": e.query}
+}
+
+func (e *queryError) StackTrace() frames {
+	return e.stack
+}
+
+// panicError panics when asked for its fields
+type panicError struct{}
+
+func (panicError) Error() string {
+	return "broken"
+}
+
+func (panicError) LogFields() map[string]any {
+	panic("broken")
+}
+
+func TestErrors(t *testing.T) {
+	cause := newQueryError("SELECT 1")
+	err := fmt.Errorf("load users: %w", errors.Join(cause, errors.New("pool closed")))
+
+	Convey("Given a logger writing text", t, func() {
+		out := &pipe{}
+		logger := log.New(log.NewFdWriters(out), config.LogOptions{})
+
+		Convey("When a wrapped error is logged", func() {
+			logger.Warn("giving up:", err)
+
+			Convey("It should print the error chain as a tree", func() {
+				So(out.String(), ShouldStartWith, "[WARN]  giving up: load users: query timed out\npool closed\n"+
+					"    *fmt.wrapError: load users: query timed out; pool closed\n"+
+					"    └─ *errors.joinError\n"+
+					"       ├─ *log_test.queryError: query timed out query=SELECT 1\n"+
+					"       │    at github.com/rish1988/go-log_test.TestErrors ")
+				So(out.String(), ShouldEndWith, "       └─ *errors.errorString: pool closed\n")
+			})
+		})
+
+		Convey("When a plain error is logged", func() {
+			logger.Warn(errors.New("disk full"))
+
+			Convey("It should only print the message", func() {
+				So(out.String(), ShouldEqual, "[WARN]  disk full\n")
+			})
+		})
+	})
+
+	Convey("Given a logger writing JSON", t, func() {
+		out := &pipe{}
+		logger, _ := log.NewLogger(log.WithOutput(out), log.WithJSON())
+
+		Convey("When a wrapped error is logged", func() {
+			logger.Errorf("giving up: %v", err)
+
+			Convey("It should write the error chain as nested objects", func() {
+				var rec struct {
+					Errors []log.ErrorInfo
+				}
+				So(json.Unmarshal(out.Bytes(), &rec), ShouldBeNil)
+				So(rec.Errors, ShouldHaveLength, 1)
+				So(rec.Errors[0].Type, ShouldEqual, "*fmt.wrapError")
+				joined := rec.Errors[0].Causes[0]
+				So(joined.Causes[0].Fields, ShouldResemble, log.Fields{"query": "SELECT 1"})
+				So(joined.Causes[0].Stack[0].Function, ShouldEqual, "github.com/rish1988/go-log_test.TestErrors")
+				So(joined.Causes[1].Message, ShouldEqual, "pool closed")
+			})
+		})
+
+		Convey("When a nil error pointer is logged", func() {
+			var nilErr *queryError
+			logger.Error("giving up:", nilErr)
+
+			Convey("It should describe it as nil", func() {
+				var rec struct {
+					Errors []log.ErrorInfo
+				}
+				So(json.Unmarshal(out.Bytes(), &rec), ShouldBeNil)
+				So(rec.Errors, ShouldHaveLength, 1)
+				So(rec.Errors[0].Type, ShouldEqual, "*log_test.queryError")
+				So(rec.Errors[0].Message, ShouldEqual, "<nil>")
+			})
+		})
+
+		Convey("When an error with a panicking method is logged", func() {
+			logger.Error("giving up:", fmt.Errorf("load users: %w", panicError{}))
+
+			Convey("It should record the panic", func() {
+				var rec struct {
+					Errors []log.ErrorInfo
+				}
+				So(json.Unmarshal(out.Bytes(), &rec), ShouldBeNil)
+				So(rec.Errors[0].Causes[0].Message, ShouldEqual, "<PANIC=broken>")
+			})
+		})
+	})
+}
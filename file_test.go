@@ -0,0 +1,182 @@
+//go:build unix
+
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the log file options
+
+package log_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/clock/clocktest"
+	"github.com/rish1988/go-log/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLogFile(t *testing.T) {
+	Convey("Given file options for a missing log directory", t, func() {
+		dir := filepath.Join(t.TempDir(), "var", "log", "app")
+		opts := config.LogOptions{
+			FileOptions: &config.FileOptions{
+				LogsDir:  dir,
+				FileName: "app",
+				TimeZone: "UTC",
+			},
+		}
+
+		Convey("When opened without creating directories", func() {
+			logger, err := log.Open(log.NewFdWriters(&pipe{}), opts)
+
+			Convey("It should fail on the log directory", func() {
+				So(logger, ShouldBeNil)
+				var fe *config.FieldError
+				So(errors.As(err, &fe), ShouldBeTrue)
+				So(fe.Field, ShouldEqual, "FileOptions.LogsDir")
+				_, err = os.Stat(dir)
+				So(os.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+
+		Convey("When opened with directory creation, modes and group", func() {
+			opts.CreateDirs = true
+			opts.DirMode = 0750
+			// Wider than the usual umask lets through
+			opts.FileMode = 0666
+			opts.Group = strconv.Itoa(os.Getgid())
+			// A umask narrower than both modes
+			umask := syscall.Umask(077)
+			Reset(func() { syscall.Umask(umask) })
+
+			logger, err := log.Open(log.NewFdWriters(&pipe{}), opts)
+			So(err, ShouldBeNil)
+			Reset(logger.Stop)
+
+			Convey("It should create the directory tree with the directory mode", func() {
+				for _, d := range []string{dir, filepath.Dir(dir), filepath.Dir(filepath.Dir(dir))} {
+					info, err := os.Stat(d)
+					So(err, ShouldBeNil)
+					So(info.IsDir(), ShouldBeTrue)
+					So(info.Mode().Perm(), ShouldEqual, os.FileMode(0750))
+				}
+			})
+
+			Convey("It should open the log file with the exact file mode and group", func() {
+				info, err := logger.GetLogFile().Stat()
+				So(err, ShouldBeNil)
+				So(info.Mode().Perm(), ShouldEqual, os.FileMode(0666))
+				So(int(info.Sys().(*syscall.Stat_t).Gid), ShouldEqual, os.Getgid())
+			})
+		})
+	})
+}
+
+func TestLogFileRotation(t *testing.T) {
+	Convey("Given a logger rotating hourly on a fake clock", t, func() {
+		dir := t.TempDir()
+		clk := clocktest.NewFake(time.Date(2026, time.October, 17, 9, 30, 0, 0, time.UTC))
+		logger, err := log.Open(log.NewFdWriters(&pipe{}), config.LogOptions{
+			Clock: clk,
+			FileOptions: &config.FileOptions{
+				LogsDir:          dir,
+				FileName:         "app",
+				TimeZone:         "UTC",
+				FileNameTemplate: "{name}-{time:2006-01-02T15}.log",
+				LinkName:         "app.log",
+				RotationPolicyOptions: &config.RotationPolicyOptions{
+					RotationInterval: "@hourly",
+					MaxFiles:         24,
+				},
+			},
+		})
+		So(err, ShouldBeNil)
+		Reset(logger.Stop)
+
+		link := filepath.Join(dir, "app.log")
+		target, err := os.Readlink(link)
+		So(err, ShouldBeNil)
+		So(target, ShouldEqual, "app-2026-10-17T09.log")
+
+		Convey("When the clock passes the hour", func() {
+			// Wait for the scheduler to be waiting on the rotation
+			clk.BlockUntil(1)
+			clk.Advance(30 * time.Minute)
+
+			Convey("It should open the next file and move the link to it", func() {
+				deadline := time.Now().Add(2 * time.Second)
+				for time.Now().Before(deadline) {
+					if target, _ = os.Readlink(link); target == "app-2026-10-17T10.log" {
+						break
+					}
+					time.Sleep(time.Millisecond)
+				}
+				So(target, ShouldEqual, "app-2026-10-17T10.log")
+				for _, name := range []string{"app-2026-10-17T09.log", "app-2026-10-17T10.log"} {
+					_, err := os.Stat(filepath.Join(dir, name))
+					So(err, ShouldBeNil)
+				}
+			})
+		})
+	})
+}
+
+func TestLogFileColor(t *testing.T) {
+	Convey("Given a logger writing to a console and a log file with color forced on", t, func() {
+		out := &pipe{}
+		logger, err := log.Open(log.NewFdWriters(out), config.LogOptions{
+			ColorOptions: config.ColorOptions{Color: true},
+			FileOptions: &config.FileOptions{
+				LogsDir:  t.TempDir(),
+				FileName: "app",
+				TimeZone: "UTC",
+			},
+		})
+		So(err, ShouldBeNil)
+		Reset(logger.Stop)
+
+		Convey("When it logs", func() {
+			logger.Warn("slow")
+			logger.WithColor().Info("ready")
+
+			Convey("It should paint the console only", func() {
+				So(out.String(), ShouldContainSubstring, "\033[")
+				data, err := os.ReadFile(logger.GetLogFile().Name())
+				So(err, ShouldBeNil)
+				So(string(data), ShouldEqual, "[WARN]  slow\n[INFO]  ready\n")
+			})
+		})
+	})
+
+	Convey("Given FORCE_COLOR is set when a file logger is created", t, func() {
+		setColorEnv(t, map[string]string{"FORCE_COLOR": "1"})
+		out := &pipe{}
+		logger, err := log.Open(log.NewFdWriters(out), config.LogOptions{
+			FileOptions: &config.FileOptions{
+				LogsDir:  t.TempDir(),
+				FileName: "app",
+				TimeZone: "UTC",
+			},
+		})
+		So(err, ShouldBeNil)
+		Reset(logger.Stop)
+
+		Convey("When it logs", func() {
+			logger.Info("ready")
+
+			Convey("It should paint the console only", func() {
+				So(out.String(), ShouldContainSubstring, "\033[0;32m")
+				data, err := os.ReadFile(logger.GetLogFile().Name())
+				So(err, ShouldBeNil)
+				So(string(data), ShouldEqual, "[INFO]  ready\n")
+			})
+		})
+	})
+}
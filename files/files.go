@@ -3,8 +3,10 @@ package files
 import (
 	"fmt"
 	"os"
+	"os/user"
 	"path/filepath"
 	"sort"
+	"strconv"
 )
 
 func Remove(rootDir string, toKeep int) ([]os.DirEntry, error) {
@@ -12,7 +14,7 @@ func Remove(rootDir string, toKeep int) ([]os.DirEntry, error) {
 	if len(rootDir) != 0 {
 		if files, err := os.ReadDir(rootDir); err != nil {
 			return nil, fmt.Errorf("failed to read log directory [ %s ] contents. Reason: %s", rootDir, err)
-		} else if len(files) > toKeep {
+		} else if files = withoutSymlinks(files); len(files) > toKeep {
 			sortFilesByModTime(files)
 			xrfiles = files[:len(files)-toKeep]
 			if err = remove(rootDir, xrfiles); err != nil {
@@ -32,6 +34,103 @@ func DirExists(dirName string) bool {
 	return true
 }
 
+// MkdirAll creates dirName along with any missing parents using mode. The
+// mode of the created directories is applied explicitly so it is not narrowed
+// by the process umask, the existing parents are left alone.
+func MkdirAll(dirName string, mode os.FileMode) error {
+	var missing []string
+	for dir := filepath.Clean(dirName); ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			break
+		}
+		missing = append(missing, dir)
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+
+	if err := os.MkdirAll(dirName, mode); err != nil {
+		return fmt.Errorf("failed to create log directory [ %s ]. Reason: %s", dirName, err)
+	}
+
+	for _, dir := range missing {
+		if err := os.Chmod(dir, mode); err != nil {
+			return fmt.Errorf("failed to set mode of log directory [ %s ]. Reason: %s", dir, err)
+		}
+	}
+	return nil
+}
+
+// OpenLog opens fileName for appending, creating it if needed. The mode is
+// applied explicitly so it is not narrowed by the process umask, and group
+// ownership is handed to gid unless it is negative.
+func OpenLog(fileName string, mode os.FileMode, gid int) (*os.File, error) {
+	file, err := os.OpenFile(fileName, os.O_APPEND|os.O_WRONLY|os.O_CREATE, mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file [ %s ]. Reason: %s", fileName, err)
+	}
+
+	if err = file.Chmod(mode); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to set mode of log file [ %s ]. Reason: %s", fileName, err)
+	}
+
+	if gid >= 0 {
+		if err = file.Chown(-1, gid); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to set group of log file [ %s ]. Reason: %s", fileName, err)
+		}
+	}
+	return file, nil
+}
+
+// LookupGroup resolves a group name or numeric group id to a gid
+func LookupGroup(group string) (int, error) {
+	if gid, err := strconv.Atoi(group); err == nil {
+		return gid, nil
+	}
+
+	g, err := user.LookupGroup(group)
+	if err != nil {
+		return -1, fmt.Errorf("failed to look up group [ %s ]. Reason: %s", group, err)
+	}
+	return strconv.Atoi(g.Gid)
+}
+
+// Symlink atomically points link at target, replacing any previous link. The
+// link is written relative to its own directory when possible so the log
+// directory can be moved around as a whole.
+func Symlink(target, link string) error {
+	if rel, err := filepath.Rel(filepath.Dir(link), target); err == nil {
+		target = rel
+	}
+
+	tmp := link + ".tmp"
+	_ = os.Remove(tmp)
+
+	if err := os.Symlink(target, tmp); err != nil {
+		return fmt.Errorf("failed to create symlink [ %s ]. Reason: %s", tmp, err)
+	}
+
+	if err := os.Rename(tmp, link); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to replace symlink [ %s ]. Reason: %s", link, err)
+	}
+	return nil
+}
+
+// withoutSymlinks drops symlinks so they are never counted or removed as
+// old log files
+func withoutSymlinks(files []os.DirEntry) []os.DirEntry {
+	var regular []os.DirEntry
+	for _, file := range files {
+		if file.Type()&os.ModeSymlink == 0 {
+			regular = append(regular, file)
+		}
+	}
+	return regular
+}
+
 func sortFilesByModTime(files []os.DirEntry) {
 	sort.Slice(files, func(i, j int) bool {
 		if files[i] == nil || files[j] == nil {
@@ -0,0 +1,92 @@
+// Test file for files
+
+package files
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSymlink(t *testing.T) {
+	Convey("Given a log directory with two log files", t, func() {
+		dir := t.TempDir()
+		first := filepath.Join(dir, "app-01-Jan-2026.log")
+		second := filepath.Join(dir, "app-02-Jan-2026.log")
+		link := filepath.Join(dir, "app.log")
+		So(os.WriteFile(first, nil, 0600), ShouldBeNil)
+		So(os.WriteFile(second, nil, 0600), ShouldBeNil)
+
+		Convey("When the link is pointed at the first and then the second file", func() {
+			So(Symlink(first, link), ShouldBeNil)
+			So(Symlink(second, link), ShouldBeNil)
+
+			Convey("It should resolve to the second file with a relative target", func() {
+				target, err := os.Readlink(link)
+				So(err, ShouldBeNil)
+				So(target, ShouldEqual, "app-02-Jan-2026.log")
+			})
+
+			Convey("It should not be removed as an old log file", func() {
+				removed, err := Remove(dir, 1)
+				So(err, ShouldBeNil)
+				So(len(removed), ShouldEqual, 1)
+				_, err = os.Lstat(link)
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestTemplate(t *testing.T) {
+	Convey("Given a file name template with time, host and pid", t, func() {
+		tmpl, err := ParseTemplate("{name}-{time:2006-01-02T15}-{pid}.log", "02-Jan-2006")
+		So(err, ShouldBeNil)
+
+		Convey("It should render every placeholder", func() {
+			now := time.Date(2026, time.October, 17, 9, 30, 0, 0, time.UTC)
+			So(tmpl.Format("app", now), ShouldEqual, fmt.Sprintf("app-2026-10-17T09-%d.log", os.Getpid()))
+		})
+
+		Convey("It should accept an hourly rotation", func() {
+			So(tmpl.CheckSchedule("@hourly", time.Now().UTC()), ShouldBeNil)
+		})
+
+		Convey("It should reject a rotation finer than its time component", func() {
+			So(tmpl.CheckSchedule("0 */10 * * * *", time.Now().UTC()), ShouldNotBeNil)
+		})
+
+		Convey("It should accept an hourly rotation across the fall back of the zone", func() {
+			location, err := time.LoadLocation("America/New_York")
+			So(err, ShouldBeNil)
+			from := time.Date(2026, time.October, 31, 12, 0, 0, 0, location)
+			So(tmpl.CheckSchedule("@hourly", from), ShouldBeNil)
+		})
+	})
+
+	Convey("Given the default template with a date-only format", t, func() {
+		tmpl, err := ParseTemplate("", "02-Jan-2006")
+		So(err, ShouldBeNil)
+
+		Convey("It should keep the historical file name", func() {
+			now := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+			So(tmpl.Format("app", now), ShouldEqual, "app-17-Oct-2026.log")
+		})
+
+		Convey("It should reject an hourly rotation", func() {
+			So(tmpl.CheckSchedule("@hourly", time.Now().UTC()), ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given a template with an unknown placeholder", t, func() {
+		_, err := ParseTemplate("{name}-{user}.log", "02-Jan-2006")
+
+		Convey("It should fail to compile", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,149 @@
+package files
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+// DefaultTemplate reproduces the historical <name>-<date>.log file naming
+const DefaultTemplate = "{name}-{time}.log"
+
+type segmentKind int
+
+const (
+	literalSegment segmentKind = iota
+	nameSegment
+	timeSegment
+	hostSegment
+	pidSegment
+)
+
+type segment struct {
+	kind segmentKind
+	text string
+}
+
+// Template is a compiled log file name template. The supported placeholders
+// are {name}, {time}, {time:<Go layout>}, {host} and {pid}.
+type Template struct {
+	source   string
+	segments []segment
+}
+
+// ParseTemplate compiles a file name template. A bare {time} placeholder is
+// rendered using dateFormat.
+func ParseTemplate(source, dateFormat string) (*Template, error) {
+	if len(source) == 0 {
+		source = DefaultTemplate
+	}
+
+	tmpl := &Template{source: source}
+	rest := source
+	for len(rest) != 0 {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			tmpl.literal(rest)
+			break
+		}
+		tmpl.literal(rest[:start])
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder in file name template [ %s ]", source)
+		}
+
+		token := rest[start+1 : start+end]
+		rest = rest[start+end+1:]
+
+		name, arg, hasArg := strings.Cut(token, ":")
+		switch name {
+		case "name":
+			tmpl.segments = append(tmpl.segments, segment{kind: nameSegment})
+		case "time":
+			if !hasArg {
+				arg = dateFormat
+			}
+			if len(arg) == 0 {
+				return nil, fmt.Errorf("empty time layout in file name template [ %s ]", source)
+			}
+			tmpl.segments = append(tmpl.segments, segment{kind: timeSegment, text: arg})
+		case "host":
+			host, err := os.Hostname()
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve hostname for file name template [ %s ]. Reason: %s", source, err)
+			}
+			tmpl.segments = append(tmpl.segments, segment{kind: hostSegment, text: host})
+		case "pid":
+			tmpl.segments = append(tmpl.segments, segment{kind: pidSegment, text: strconv.Itoa(os.Getpid())})
+		default:
+			return nil, fmt.Errorf("unknown placeholder {%s} in file name template [ %s ]", token, source)
+		}
+	}
+
+	if strings.ContainsRune(tmpl.Format("x", time.Now()), os.PathSeparator) {
+		return nil, fmt.Errorf("file name template [ %s ] must not contain path separators", source)
+	}
+	return tmpl, nil
+}
+
+func (t *Template) literal(text string) {
+	if len(text) != 0 {
+		t.segments = append(t.segments, segment{kind: literalSegment, text: text})
+	}
+}
+
+// String returns the template source
+func (t *Template) String() string {
+	return t.source
+}
+
+// Format renders the file name for the given base name and time
+func (t *Template) Format(name string, now time.Time) string {
+	var b strings.Builder
+	for _, s := range t.segments {
+		switch s.kind {
+		case nameSegment:
+			b.WriteString(name)
+		case timeSegment:
+			b.WriteString(now.Format(s.text))
+		default:
+			b.WriteString(s.text)
+		}
+	}
+	return b.String()
+}
+
+// CheckSchedule verifies that the rendered file name changes on every run of
+// the rotation schedule following from, so a rotation never reopens the file
+// it just closed
+func (t *Template) CheckSchedule(spec string, from time.Time) error {
+	schedule, err := cron.Parse(spec)
+	if err != nil {
+		return fmt.Errorf("invalid rotation interval [ %s ]. Reason: %s", spec, err)
+	}
+
+	// Walk a couple of days worth of runs, enough to catch hourly schedules
+	// paired with a date-only layout as well as sub-minute schedules
+	prev := schedule.Next(from)
+	for i := 0; i < 48; i++ {
+		next := schedule.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		// The wall clock repeats an hour when the zone falls back, runs
+		// across the offset change may share a name only that night
+		_, prevOffset := prev.Zone()
+		_, nextOffset := next.Zone()
+		if prevOffset == nextOffset && t.Format("", prev) == t.Format("", next) {
+			return fmt.Errorf("file name template [ %s ] is coarser than rotation interval [ %s ]: runs at %s and %s share a file name",
+				t.source, spec, prev.Format(time.RFC3339), next.Format(time.RFC3339))
+		}
+		prev = next
+	}
+	return nil
+}
@@ -3,9 +3,11 @@ module github.com/rish1988/go-log
 go 1.21.5
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/robfig/cron v1.2.0
 	github.com/smartystreets/goconvey v1.8.1
 	golang.org/x/crypto v0.18.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
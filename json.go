@@ -0,0 +1,91 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rish1988/go-log/buffer"
+)
+
+// reservedKeys are the keys of the JSON record itself, fields using them are
+// written as "fields.<key>" instead
+var reservedKeys = map[string]bool{
+	"time":     true,
+	"level":    true,
+	"logger":   true,
+	"caller":   true,
+	"function": true,
+	"message":  true,
+	"stack":    true,
+	"errors":   true,
+}
+
+// appendJSON append rec to buf as a single line JSON object
+func appendJSON(buf *buffer.Buffer, rec *Record, level string, caller bool) {
+	buf.AppendByte('{')
+	appendJSONPair(buf, "time", rec.Time.Format(time.RFC3339Nano), false)
+	appendJSONPair(buf, "level", level, true)
+	if len(rec.Logger) != 0 {
+		appendJSONPair(buf, "logger", rec.Logger, true)
+	}
+	if caller {
+		appendJSONPair(buf, "caller", fmt.Sprintf("%s:%d", filepath.Base(rec.Caller.File), rec.Caller.Line), true)
+		appendJSONPair(buf, "function", rec.Caller.Function, true)
+	}
+	appendJSONPair(buf, "message", rec.Message, true)
+	if len(rec.Stack) != 0 {
+		appendJSONPair(buf, "stack", rec.Stack, true)
+	}
+	if len(rec.Errors) != 0 {
+		appendJSONPair(buf, "errors", rec.Errors, true)
+	}
+
+	keys := make([]string, 0, len(rec.Fields))
+	for k := range rec.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		name := k
+		if reservedKeys[k] {
+			name = "fields." + k
+		}
+		appendJSONPair(buf, name, rec.Fields[k], true)
+	}
+	buf.Append([]byte("}\n"))
+}
+
+func appendJSONPair(buf *buffer.Buffer, key string, value interface{}, comma bool) {
+	if comma {
+		buf.AppendByte(',')
+	}
+	appendJSONValue(buf, key)
+	buf.AppendByte(':')
+	appendJSONValue(buf, value)
+}
+
+// appendJSONValue append the JSON encoding of value, falling back to its
+// string form for values that cannot be encoded
+func appendJSONValue(buf *buffer.Buffer, value interface{}) {
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(value))
+	}
+	buf.Append(data)
+}
+
+// prefixLevel derive the level name from a prefix such as "[WARN]  " for
+// records written through Output directly
+func prefixLevel(prefix Prefix) string {
+	return strings.ToLower(strings.Trim(string(prefix.Plain), "[] "))
+}
@@ -0,0 +1,147 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/rish1988/go-log/colorful"
+	"github.com/rish1988/go-log/config"
+	"github.com/rish1988/go-log/layout"
+)
+
+// lineLayout is a compiled layout along with the time stamps of its time
+// placeholders, resolved once
+type lineLayout struct {
+	*layout.Layout
+	// Time stamps by part index, set for the time placeholders with a format
+	timeStamps []timeStamp
+}
+
+// layoutOf compiles the line layout of the options, nil for the default one
+func layoutOf(options config.LogOptions) *lineLayout {
+	if len(options.Layout) == 0 {
+		return nil
+	}
+	compiled, err := layout.Parse(options.Layout)
+	if err != nil {
+		return nil
+	}
+	parts := compiled.Parts()
+	ll := &lineLayout{Layout: compiled, timeStamps: make([]timeStamp, len(parts))}
+	for i, part := range parts {
+		if part.Verb == layout.Time && len(part.Arg) != 0 {
+			ll.timeStamps[i] = parseTimeStamp(part.Arg, "", "")
+		}
+	}
+	return ll
+}
+
+func (l *Logger) getLayout() *lineLayout {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.layout
+}
+
+// layoutHas check whether the logger layout has a placeholder for verb
+func (l *Logger) layoutHas(verb layout.Verb) bool {
+	lineLayout := l.getLayout()
+	return lineLayout != nil && lineLayout.Has(verb)
+}
+
+// appendLayout renders rec into the buffers following lineLayout. A
+// placeholder rendering nothing, like the fields of a record without any,
+// also swallows the space separating it from the next part. The caller holds
+// the lock.
+func (l *Logger) appendLayout(lineLayout *lineLayout, color bool, rec *Record, callerInfo *frame, prefix Prefix, data Message) {
+	skipSpace := false
+	for i, part := range lineLayout.Parts() {
+		if part.Verb == layout.Literal {
+			text := part.Arg
+			if skipSpace {
+				text = strings.TrimPrefix(text, " ")
+			}
+			l.colorBuf.Append([]byte(text))
+			l.noColorBuf.Append([]byte(text))
+			skipSpace = false
+			continue
+		}
+
+		var (
+			text  string
+			paint colorful.Color
+		)
+		switch part.Verb {
+		case layout.Time:
+			stamp := l.timeStamp
+			if len(part.Arg) != 0 {
+				stamp = lineLayout.timeStamps[i]
+			}
+			start := len(l.noColorBuf.Buffer)
+			l.noColorBuf.Buffer = stamp.appendTo(l.noColorBuf.Buffer, rec.Time)
+			l.appendPainted(color, l.palette.timeStamp, start)
+			continue
+		case layout.Level:
+			text, paint = part.Pad(rec.Level.String()), l.palette.level(rec.Level)
+		case layout.Prefix:
+			if color {
+				l.colorBuf.Append(prefix.Color)
+			} else {
+				l.colorBuf.Append(prefix.Plain)
+			}
+			l.noColorBuf.Append(prefix.Plain)
+			skipSpace = len(prefix.Plain) == 0
+			continue
+		case layout.Logger:
+			text = part.Pad(rec.Logger)
+			if color && len(rec.Logger) != 0 {
+				l.appendName(true, rec.Logger, text)
+				skipSpace = false
+				continue
+			}
+		case layout.Caller:
+			format := part.Arg
+			if len(format) == 0 {
+				format = l.callerFormat
+			}
+			if len(format) == 0 {
+				format = callerShort
+			}
+			start := len(l.noColorBuf.Buffer)
+			appendCaller(&l.noColorBuf.Buffer, format, rec.Caller, callerInfo)
+			l.appendPainted(color, l.palette.caller, start)
+			continue
+		case layout.Message:
+			text = strings.TrimSuffix(string(data.Plain), "\n")
+			paint = l.palette.level(rec.Level)
+		case layout.Fields:
+			text = strings.TrimPrefix(rec.Fields.String(), " ")
+			if color && len(text) != 0 && l.palette.fields() {
+				var cb colorful.ColorBuffer
+				rec.Fields.appendTo(&cb, l.palette.fieldKey, l.palette.fieldValue)
+				l.colorBuf.Append(cb.Buffer[1:])
+				l.noColorBuf.AppendString(text)
+				skipSpace = false
+				continue
+			}
+		}
+
+		skipSpace = len(text) == 0
+		if color && paint != nil && len(text) != 0 {
+			l.colorBuf.Append(paint([]byte(text)))
+		} else {
+			l.colorBuf.Append([]byte(text))
+		}
+		l.noColorBuf.Append([]byte(text))
+	}
+
+	// Drop the separator in front of a trailing empty placeholder
+	if skipSpace {
+		l.colorBuf.Buffer = bytes.TrimSuffix(l.colorBuf.Buffer, []byte(" "))
+		l.noColorBuf.Buffer = bytes.TrimSuffix(l.noColorBuf.Buffer, []byte(" "))
+	}
+	l.colorBuf.AppendByte('\n')
+	l.noColorBuf.AppendByte('\n')
+}
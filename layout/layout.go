@@ -0,0 +1,173 @@
+// Line layout templates for the go-log library
+
+package layout
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Verb identify what a layout part renders
+type Verb int
+
+const (
+	// Literal text between placeholders
+	Literal Verb = iota
+	// Time of the record, Arg holds the Go time layout
+	Time
+	// Level name, Width pads it
+	Level
+	// Prefix as configured for the level, including its color
+	Prefix
+	// Logger name
+	Logger
+	// Caller info, Arg is one of the caller formats of the logger
+	// options, empty for the logger default
+	Caller
+	// Message text
+	Message
+	// Fields as space separated key=value pairs
+	Fields
+)
+
+// Part is a single literal or placeholder of a layout
+type Part struct {
+	Verb  Verb
+	Arg   string
+	Width int
+}
+
+// Layout is a compiled line layout such as
+// "%{time:15:04:05.000} %{level:-5} [%{logger}] %{caller:short} %{message} %{fields}"
+type Layout struct {
+	source string
+	parts  []Part
+}
+
+var verbs = map[string]Verb{
+	"time":    Time,
+	"level":   Level,
+	"prefix":  Prefix,
+	"logger":  Logger,
+	"caller":  Caller,
+	"message": Message,
+	"fields":  Fields,
+}
+
+// Parse compiles a layout pattern. Placeholders are written %{verb} or
+// %{verb:arg}, and %% prints a literal percent sign.
+func Parse(pattern string) (*Layout, error) {
+	l := &Layout{source: pattern}
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() != 0 {
+			l.parts = append(l.parts, Part{Verb: Literal, Arg: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' || i+1 == len(pattern) {
+			literal.WriteByte(pattern[i])
+			continue
+		}
+		if pattern[i+1] == '%' {
+			literal.WriteByte('%')
+			i++
+			continue
+		}
+		if pattern[i+1] != '{' {
+			literal.WriteByte(pattern[i])
+			continue
+		}
+
+		end := strings.IndexByte(pattern[i:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder in layout [ %s ]", pattern)
+		}
+		part, err := parsePart(pattern[i+2 : i+end])
+		if err != nil {
+			return nil, fmt.Errorf("%s in layout [ %s ]", err, pattern)
+		}
+		flush()
+		l.parts = append(l.parts, part)
+		i += end
+	}
+	flush()
+
+	if !l.Has(Message) {
+		return nil, fmt.Errorf("layout [ %s ] has no %%{message} placeholder", pattern)
+	}
+	return l, nil
+}
+
+func parsePart(token string) (Part, error) {
+	name, arg, _ := strings.Cut(token, ":")
+	verb, ok := verbs[name]
+	if !ok {
+		return Part{}, fmt.Errorf("unknown placeholder %%{%s}", token)
+	}
+
+	part := Part{Verb: verb, Arg: arg}
+	switch verb {
+	case Level, Logger:
+		if len(arg) != 0 {
+			width, err := strconv.Atoi(arg)
+			if err != nil {
+				return Part{}, fmt.Errorf("invalid width in %%{%s}", token)
+			}
+			part.Width, part.Arg = width, ""
+		}
+	case Caller:
+		switch arg {
+		case "", "full", "short", "relative", "long", "func", "package":
+		default:
+			return Part{}, fmt.Errorf("unknown caller format in %%{%s}", token)
+		}
+	case Time:
+	default:
+		if len(arg) != 0 {
+			return Part{}, fmt.Errorf("unexpected argument in %%{%s}", token)
+		}
+	}
+	return part, nil
+}
+
+// String returns the layout pattern
+func (l *Layout) String() string {
+	return l.source
+}
+
+// Parts returns the compiled parts in order
+func (l *Layout) Parts() []Part {
+	return l.parts
+}
+
+// Has reports whether the layout contains a placeholder for verb
+func (l *Layout) Has(verb Verb) bool {
+	for _, p := range l.parts {
+		if p.Verb == verb {
+			return true
+		}
+	}
+	return false
+}
+
+// Pad pads s to the part width, on the left for positive widths and on the
+// right for negative ones like the fmt package does
+func (p Part) Pad(s string) string {
+	width := p.Width
+	left := width < 0
+	if left {
+		width = -width
+	}
+	if len(s) >= width {
+		return s
+	}
+	padding := strings.Repeat(" ", width-len(s))
+	if left {
+		return s + padding
+	}
+	return padding + s
+}
@@ -0,0 +1,57 @@
+// Test file for layout
+
+package layout
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse(t *testing.T) {
+	Convey("Given a layout with every kind of placeholder", t, func() {
+		l, err := Parse("%{time:15:04:05.000} %{level:-5} [%{logger}] %{caller:long} %{message} %{fields} 100%%")
+		So(err, ShouldBeNil)
+
+		Convey("It should compile into literals and placeholders in order", func() {
+			So(l.Parts(), ShouldResemble, []Part{
+				{Verb: Time, Arg: "15:04:05.000"},
+				{Verb: Literal, Arg: " "},
+				{Verb: Level, Width: -5},
+				{Verb: Literal, Arg: " ["},
+				{Verb: Logger},
+				{Verb: Literal, Arg: "] "},
+				{Verb: Caller, Arg: "long"},
+				{Verb: Literal, Arg: " "},
+				{Verb: Message},
+				{Verb: Literal, Arg: " "},
+				{Verb: Fields},
+				{Verb: Literal, Arg: " 100%"},
+			})
+			So(l.Has(Fields), ShouldBeTrue)
+			So(l.Has(Prefix), ShouldBeFalse)
+		})
+
+		Convey("It should pad like the fmt package", func() {
+			So(Part{Width: -5}.Pad("INFO"), ShouldEqual, "INFO ")
+			So(Part{Width: 5}.Pad("INFO"), ShouldEqual, " INFO")
+			So(Part{Width: 3}.Pad("ERROR"), ShouldEqual, "ERROR")
+		})
+	})
+
+	Convey("Given invalid layouts", t, func() {
+		Convey("It should reject them", func() {
+			for _, pattern := range []string{
+				"%{level}",
+				"%{message",
+				"%{colour} %{message}",
+				"%{level:wide} %{message}",
+				"%{caller:basename} %{message}",
+				"%{message:upper}",
+			} {
+				_, err := Parse(pattern)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
@@ -0,0 +1,62 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the line layout
+
+package log_test
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/clock/clocktest"
+	"github.com/rish1988/go-log/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLayout(t *testing.T) {
+	Convey("Given a logger with a custom layout", t, func() {
+		out := &pipe{}
+		clk := clocktest.NewFake(time.Date(2026, 1, 2, 15, 4, 5, 123e6, time.Local))
+		logger, err := log.NewLogger(
+			log.WithOutput(out),
+			log.WithClock(clk),
+			log.WithLayout("%{time:15:04:05.000} %{level:-5} %{caller:short} %{message} %{fields}"),
+		)
+		So(err, ShouldBeNil)
+
+		Convey("When a record with fields is logged", func() {
+			logger.WithFields(log.Fields{"user": "ann"}).Info("signed in")
+
+			Convey("It should render the placeholders in order", func() {
+				So(out.String(), ShouldEqual, "15:04:05.123 INFO  layout_test.go:30 signed in user=ann\n")
+			})
+		})
+
+		Convey("When a record without fields is logged", func() {
+			logger.Warn("disk low")
+
+			Convey("It should drop the separator of the empty fields", func() {
+				So(out.String(), ShouldEndWith, " disk low\n")
+			})
+		})
+
+		Convey("When color is forced on", func() {
+			logger.WithColor().Error("failed")
+
+			Convey("It should color level, time and caller", func() {
+				So(out.String(), ShouldStartWith, "\033[0;34m15:04:05.123\033[0m \033[0;31mERROR\033[0m \033[0;33m")
+			})
+		})
+	})
+
+	Convey("Given an invalid layout", t, func() {
+		_, err := log.Open(log.NewFdWriters(&pipe{}), config.LogOptions{Layout: "%{level}"})
+
+		Convey("It should fail validation", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "Layout")
+		})
+	})
+}
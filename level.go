@@ -0,0 +1,112 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"strings"
+
+	"github.com/rish1988/go-log/config"
+)
+
+// SetLevel change the least severe level written by the logger
+func (l *Logger) SetLevel(level MessageType) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+	l.options.Level = config.LevelName(int(level))
+}
+
+// SetQuiet enable or disable the quiet state
+func (l *Logger) SetQuiet(quiet bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.quiet = quiet
+	l.options.Quiet = quiet
+}
+
+// SetPackageLevel override the logger level for messages logged from the
+// package with the given import path and its sub packages
+func (l *Logger) SetPackageLevel(pkg string, level MessageType) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	levels := make(map[string]MessageType, len(l.packageLevels)+1)
+	for k, v := range l.packageLevels {
+		levels[k] = v
+	}
+	levels[pkg] = level
+	l.packageLevels = levels
+}
+
+// ClearPackageLevel remove the level override of a package
+func (l *Logger) ClearPackageLevel(pkg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	levels := make(map[string]MessageType, len(l.packageLevels))
+	for k, v := range l.packageLevels {
+		if k != pkg {
+			levels[k] = v
+		}
+	}
+	l.packageLevels = levels
+}
+
+// PackageLevels returns a copy of the per package level overrides
+func (l *Logger) PackageLevels() map[string]MessageType {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	levels := make(map[string]MessageType, len(l.packageLevels))
+	for k, v := range l.packageLevels {
+		levels[k] = v
+	}
+	return levels
+}
+
+// enabled check whether a message at level logged by the caller of the
+// level method is written, taking the package and logger name overrides
+// into account
+func (l *Logger) enabled(level MessageType) bool {
+	l.mu.RLock()
+	threshold, levels, named := l.level, l.packageLevels, l.loggerLevels
+	l.mu.RUnlock()
+
+	if len(levels) != 0 {
+		// Skip enabled and the level method to reach the caller
+		_, f := callerFrame(2 + l.callerSkip)
+		if override, ok := packageLevel(levels, f.pkg); ok {
+			threshold = override
+		}
+	}
+	// Overrides of the logger name are more specific than package ones
+	if len(named) != 0 && len(l.name) != 0 {
+		if override, ok := loggerLevel(named, l.name); ok {
+			threshold = override
+		}
+	}
+	return level <= threshold
+}
+
+// packageLevel finds the override of the longest package path matching pkg
+func packageLevel(levels map[string]MessageType, pkg string) (MessageType, bool) {
+	for {
+		if level, ok := levels[pkg]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(pkg, '/')
+		if i < 0 {
+			return 0, false
+		}
+		pkg = pkg[:i]
+	}
+}
+
+// funcPackage returns the import path of the package a function name as
+// reported by runtime.FuncForPC belongs to, e.g. "github.com/a/b" for
+// "github.com/a/b.(*T).Method"
+func funcPackage(name string) string {
+	slash := strings.LastIndexByte(name, '/')
+	if dot := strings.IndexByte(name[slash+1:], '.'); dot >= 0 {
+		return name[:slash+1+dot]
+	}
+	return name
+}
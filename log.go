@@ -5,6 +5,7 @@ package log
 
 import (
 	"fmt"
+	"github.com/rish1988/go-log/clock"
 	"github.com/rish1988/go-log/colorful"
 	"github.com/rish1988/go-log/config"
 	"github.com/rish1988/go-log/cronjob"
@@ -14,11 +15,9 @@ import (
 	"math"
 	"os"
 	"path/filepath"
-	"runtime"
+	"strings"
 	"sync"
 	"time"
-
-	"golang.org/x/crypto/ssh/terminal"
 )
 
 // FdWriter interface extends existing io.Writer with file descriptor function
@@ -34,37 +33,72 @@ func NewFdWriters(files ...FdWriter) FdWriters {
 	return files
 }
 
+// Write write the colored t to the writers that get color, see ColorEnabled,
+// and the plain p to the others.
+//
+// Deprecated: color is detected again on every call, loggers detect it once
+// per writer instead.
 func (f *FdWriters) Write(t []byte, p []byte) (n int, err error) {
-	for _, writer := range *f {
-		isTerminal := terminal.IsTerminal(int(writer.Fd()))
+	return f.writeColored("", colorAuto, colorWriters(*f), t, p)
+}
 
-		if isTerminal {
-			if n, err = writer.Write(t); err != nil {
-				return n, err
-			}
-		} else if n, err = writer.Write(p); err != nil {
+// writeAll write the same data to every writer taking the lines of the
+// logger named name
+func (f *FdWriters) writeAll(name string, data []byte) (n int, err error) {
+	for _, writer := range *f {
+		if !accepts(writer, name) {
+			continue
+		}
+		if n, err = writer.Write(data); err != nil {
 			return n, err
-
 		}
 	}
-	return len(t), nil
+	return len(data), nil
 }
 
 // Logger struct define the underlying storage for single logger
 type Logger struct {
+	*core
+	fields     Fields
+	callerSkip int
+	name       string
+}
+
+// core holds the state shared by a logger and the loggers derived from it
+type core struct {
 	mu            sync.RWMutex
 	color         bool
+	colored       []bool
+	colorMode     colorMode
+	json          bool
+	layout        *lineLayout
 	out           FdWriters
-	debug         bool
+	level         MessageType
 	timestamp     bool
 	quiet         bool
-	colorSettings config.ColorOptions
+	palette       palette
+	prefixes      [Trace + 1]Prefix
 	colorBuf      colorful.ColorBuffer
 	noColorBuf    colorful.ColorBuffer
-	cron          *cron.Cron
+	scheduler     *cronjob.Scheduler
+	ownScheduler  bool
+	jobs          []int
+	stopped       bool
+	watchers      []*Watcher
 	logFile       *os.File
-	timeFormat    string
+	timeStamp     timeStamp
+	callerFormat  string
+	stack         *stackPolicy
+	utc           bool
 	timeZone      *time.Location
+	clock         clock.Clock
+	hooks         []Hook
+	options       config.LogOptions
+	baseOut       FdWriters
+	generation    int
+	reconfigure   sync.Mutex
+	packageLevels map[string]MessageType
+	loggerLevels  map[string]MessageType
 }
 
 // Prefix struct define plain and color byte
@@ -128,10 +162,12 @@ type Message struct {
 	Color []byte
 }
 
+// MessageType is the level of a log message, from the most severe Fatal to
+// the least severe Trace
 type MessageType int
 
 const (
-	Fatal = iota
+	Fatal MessageType = iota
 	Error
 	Warn
 	Info
@@ -139,135 +175,184 @@ const (
 	Trace
 )
 
-func (l *Logger) coloredMessage(messageType MessageType, data string) Message {
-	if len(data) == 0 || data[len(data)-1] != '\n' {
-		data = data + "\n"
+func (l *Logger) coloredMessage(messageType MessageType, data string, fields Fields) Message {
+	data = strings.TrimSuffix(data, "\n")
+
+	l.mu.RLock()
+	color := l.palette.level(messageType)
+	var key, value []byte
+	if len(fields) != 0 && l.palette.fields() {
+		key, value = l.palette.fieldKey, l.palette.fieldValue
 	}
+	l.mu.RUnlock()
 
 	message := Message{
-		Plain: []byte(data),
+		Plain: []byte(data + fields.String() + "\n"),
 	}
 
-	switch messageType {
-	case Fatal:
-		fatalColor := l.colorSettings.Fatal
-		if fatalColor != nil {
-			message.Color = fatalColor(message.Plain)
-			FatalPrefix.Color = fatalColor(FatalPrefix.Plain)
-		} else {
-			message.Color = colorful.Red(message.Plain)
-		}
-	case Error:
-		errorColor := l.colorSettings.Error
-		if errorColor != nil {
-			message.Color = errorColor(message.Plain)
-			ErrorPrefix.Color = errorColor(ErrorPrefix.Plain)
-		} else {
-			message.Color = colorful.Red(message.Plain)
-		}
-	case Warn:
-		warnColor := l.colorSettings.Warn
-		if warnColor != nil {
-			message.Color = warnColor(message.Plain)
-			WarnPrefix.Color = warnColor(WarnPrefix.Plain)
-		} else {
-			message.Color = colorful.Orange(message.Plain)
-		}
-	case Info:
-		infoColor := l.colorSettings.Info
-		if infoColor != nil {
-			message.Color = infoColor(message.Plain)
-			InfoPrefix.Color = infoColor(InfoPrefix.Plain)
-		} else {
-			message.Color = colorful.Green(message.Plain)
-		}
-	case Debug:
-		debugColor := l.colorSettings.Debug
-		if debugColor != nil {
-			message.Color = debugColor(message.Plain)
-			DebugPrefix.Color = debugColor(DebugPrefix.Plain)
-		} else {
-			message.Color = colorful.Purple(message.Plain)
-		}
-	case Trace:
-		traceColor := l.colorSettings.Trace
-		if traceColor != nil {
-			message.Color = traceColor(message.Plain)
-			TracePrefix.Color = traceColor(TracePrefix.Plain)
+	switch {
+	case color == nil && key == nil && value == nil:
+		message.Color = message.Plain
+	case key == nil && value == nil:
+		message.Color = color(message.Plain)
+	default:
+		// Paint the fields apart from the message
+		var cb colorful.ColorBuffer
+		if color != nil {
+			cb.Append(color([]byte(data)))
 		} else {
-			message.Color = colorful.Cyan(message.Plain)
+			cb.AppendString(data)
 		}
+		fields.appendTo(&cb, key, value)
+		cb.AppendByte('\n')
+		message.Color = cb.Buffer
 	}
 	return message
 }
 
 // New returns new Logger instance with predefined writer output and
-// automatically detect terminal coloring support
+// automatically detect coloring support of every writer, see ColorEnabled.
+// Invalid options are printed and the logger falls back to the given writers,
+// use Open to handle them instead.
 func New(out FdWriters, options config.LogOptions) *Logger {
-	if options.FileOptions != nil && files.DirExists(options.LogsDir) {
-		log := getLogger(options)
-		log.cron = cronjob.NewCron(options.TimeZone)
-
-		var (
-			cronInterval string
-			maxFileCount int
-		)
-
-		if options.RotationPolicyOptions != nil {
-			cronInterval = options.RotationInterval
-			maxFileCount = options.MaxFiles
-		} else {
-			cronInterval = "@midnight"
-			maxFileCount = math.MaxInt64
-		}
+	log, err := Open(out, options)
+	if err != nil {
+		fmt.Printf("Failed to set up logger. Reason: %s\n", err)
+		return newLogger(out, options)
+	}
+	return log
+}
 
-		if err := log.cron.AddJob(cronInterval, cron.FuncJob(func() {
-			log = getLogger(options)
-			time.Sleep(time.Second)
-		})); err != nil {
-			fmt.Printf("Failed to add logger cronjob. Reason: %s\n", err)
-			return log
-		}
+// Open returns new Logger instance like New, but fails fast when the options
+// do not pass config.LogOptions.Validate or the log file configured in the
+// file options cannot be set up
+func Open(out FdWriters, options config.LogOptions) (*Logger, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
 
-		if err := log.cron.AddJob(cronInterval, cron.FuncJob(func() {
-			cleanupOldLogs(options.LogsDir, maxFileCount)
-		})); err != nil {
-			fmt.Printf("Failed to add logger cronjob to remove old log files. Reason: %s\n", err)
-			return log
-		}
-		log.cron.Start()
-		return log
+	if options.FileOptions == nil {
+		return newLogger(out, options), nil
+	}
+
+	log, err := getLogger(out, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = log.schedule(options); err != nil {
+		log.Stop()
+		log.logFile.Close()
+		return nil, err
+	}
+	return log, nil
+}
+
+// schedule adds the rotation and cleanup jobs of the file options to the
+// logger scheduler
+func (l *Logger) schedule(options config.LogOptions) error {
+	var (
+		cronInterval string
+		maxFileCount int
+	)
+
+	l.mu.RLock()
+	generation, location, clk := l.generation, l.timeZone, l.clock
+	l.mu.RUnlock()
+
+	var (
+		scheduler *cronjob.Scheduler
+		own       bool
+	)
+	if options.RotationPolicyOptions != nil {
+		cronInterval = options.RotationInterval
+		maxFileCount = options.MaxFiles
+		scheduler = options.Scheduler
+	} else {
+		cronInterval = "@midnight"
+		maxFileCount = math.MaxInt64
+	}
+
+	if scheduler == nil {
+		scheduler = cronjob.NewSchedulerWithClock(location, clk)
+		own = true
+	}
+
+	rotateJob, err := scheduler.AddJob(cronInterval, cron.FuncJob(func() {
+		l.rotate(options, generation)
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to add logger cronjob. Reason: %s", err)
 	}
 
-	return &Logger{
-		color:         isTerminal(out),
-		out:           out,
-		timestamp:     options.TimeStamp,
-		debug:         options.Debug,
-		quiet:         options.Quiet,
-		colorSettings: options.ColorOptions,
-		timeZone:      time.Now().Location(),
-		timeFormat:    "02-Jan-2006",
+	cleanupJob, err := scheduler.AddJob(cronInterval, cron.FuncJob(func() {
+		cleanupOldLogs(options.LogsDir, maxFileCount)
+	}))
+	if err != nil {
+		scheduler.Remove(rotateJob)
+		return fmt.Errorf("failed to add logger cronjob to remove old log files. Reason: %s", err)
 	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// The logger was stopped or reconfigured in the meantime
+	if l.stopped || generation != l.generation {
+		scheduler.Remove(rotateJob, cleanupJob)
+		return nil
+	}
+	l.scheduler, l.ownScheduler, l.jobs = scheduler, own, []int{rotateJob, cleanupJob}
+	scheduler.Start()
+	return nil
+}
+
+func newLogger(out FdWriters, options config.LogOptions) *Logger {
+	colored := colorWriters(out)
+	return &Logger{core: &core{
+		color:        anyColored(colored),
+		colored:      colored,
+		colorMode:    colorModeOf(options),
+		json:         options.Format == "json",
+		layout:       layoutOf(options),
+		out:          out,
+		timestamp:    options.TimeStamp,
+		level:        levelOf(options),
+		quiet:        options.Quiet,
+		palette:      paletteOf(options),
+		prefixes:     prefixesOf(options),
+		timeZone:     time.Now().Location(),
+		timeStamp:    timeStampOf(options, "02-Jan-2006"),
+		utc:          options.UTC,
+		callerFormat: options.CallerFormat,
+		stack:        stackPolicyOf(options),
+		clock:        clock.OrSystem(options.Clock),
+		options:      options,
+		baseOut:      out,
+	}}
 }
 
-func isTerminal(out FdWriters) bool {
-	for _, o := range out {
-		if terminal.IsTerminal(int(o.Fd())) {
-			return true
+// levelOf returns the least severe level enabled by the options
+func levelOf(options config.LogOptions) MessageType {
+	if len(options.Level) != 0 {
+		if level, err := config.ParseLevel(options.Level); err != nil {
+			fmt.Printf("%v\n", err)
+		} else {
+			return MessageType(level)
 		}
 	}
-	return false
+	if options.Debug {
+		return Trace
+	}
+	return Info
 }
 
-func getLogger(opts config.LogOptions) *Logger {
+// getLogger returns a logger writing to out, or to stderr when out is empty,
+// and to the log file of the file options
+func getLogger(out FdWriters, opts config.LogOptions) (*Logger, error) {
 	var (
-		writers  FdWriters
 		location *time.Location
 		err      error
 	)
 
-	file := logFile(opts.LogsDir, opts.FileName, opts.DateFormat)
 	timeZone := opts.TimeZone
 	dateFormat := opts.DateFormat
 
@@ -276,29 +361,86 @@ func getLogger(opts config.LogOptions) *Logger {
 	}
 
 	if location, err = time.LoadLocation(timeZone); err != nil {
-		fmt.Printf("Invalid timezone %s", timeZone)
+		return nil, fmt.Errorf("invalid timezone [ %s ]. Reason: %s", timeZone, err)
 	}
 
 	if len(dateFormat) == 0 {
 		dateFormat = "02-Jan-2006"
 	}
 
-	if file != nil {
-		writers = NewFdWriters(os.Stderr, file)
-	} else {
-		writers = NewFdWriters(os.Stderr)
+	tmpl, err := fileTemplate(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	clk := clock.OrSystem(opts.Clock)
+	file, err := logFile(opts.FileOptions, tmpl, clk.Now().In(location))
+	if err != nil {
+		return nil, err
 	}
 
-	return &Logger{
-		color:         isTerminal(writers),
-		out:           writers,
-		timestamp:     opts.TimeStamp,
-		debug:         opts.Debug,
-		quiet:         opts.Quiet,
-		colorSettings: opts.ColorOptions,
-		logFile:       file,
-		timeFormat:    dateFormat,
-		timeZone:      location,
+	if len(opts.LinkName) != 0 {
+		if err = files.Symlink(file.Name(), filepath.Join(opts.LogsDir, opts.LinkName)); err != nil {
+			fmt.Printf("%v\n", err)
+		}
+	}
+
+	// The log file is written plain whatever the color settings
+	writers := NewFdWriters(os.Stderr, plainWriter{file})
+	if len(out) != 0 {
+		writers = append(append(FdWriters(nil), out...), plainWriter{file})
+	}
+
+	colored := colorWriters(writers)
+	return &Logger{core: &core{
+		color:        anyColored(colored),
+		colored:      colored,
+		colorMode:    colorModeOf(opts),
+		json:         opts.Format == "json",
+		layout:       layoutOf(opts),
+		out:          writers,
+		timestamp:    opts.TimeStamp,
+		level:        levelOf(opts),
+		quiet:        opts.Quiet,
+		palette:      paletteOf(opts),
+		prefixes:     prefixesOf(opts),
+		logFile:      file,
+		timeStamp:    timeStampOf(opts, dateFormat),
+		utc:          opts.UTC,
+		callerFormat: opts.CallerFormat,
+		stack:        stackPolicyOf(opts),
+		timeZone:     location,
+		clock:        clk,
+		options:      opts,
+		baseOut:      out,
+	}}, nil
+}
+
+// rotate switches the logger over to a freshly opened log file and closes the
+// previous one. The current file is kept when the next one cannot be opened.
+func (l *Logger) rotate(opts config.LogOptions, generation int) {
+	next, err := getLogger(l.baseOut, opts)
+	if err != nil {
+		fmt.Printf("Failed to rotate log file. Reason: %s\n", err)
+		return
+	}
+
+	l.mu.Lock()
+	// The logger was reconfigured while the file was being opened
+	if generation != l.generation {
+		l.mu.Unlock()
+		next.logFile.Close()
+		return
+	}
+	prev := l.logFile
+	l.color = next.color
+	l.colored = next.colored
+	l.out = next.out
+	l.logFile = next.logFile
+	l.mu.Unlock()
+
+	if prev != nil && prev != next.logFile {
+		prev.Close()
 	}
 }
 
@@ -312,36 +454,115 @@ func cleanupOldLogs(logsDir string, maxDays int) {
 	}
 }
 
-func logFile(logsDir, fileName, dateFormat string) *os.File {
-	t := time.Now()
-	date := t.Format(dateFormat)
-	if len(logsDir) != 0 {
-		logFileName := fmt.Sprintf("%s/%s-%s.log", logsDir, fileName, date)
-		if file, err := os.OpenFile(logFileName, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600); err != nil {
-			return nil
-		} else {
-			return file
+// fileTemplate compiles the log file name template from the file options
+func fileTemplate(opts config.LogOptions) (*files.Template, error) {
+	dateFormat := opts.DateFormat
+	if len(dateFormat) == 0 {
+		dateFormat = "02-Jan-2006"
+	}
+	return files.ParseTemplate(opts.FileNameTemplate, dateFormat)
+}
+
+// logFile opens the log file for time t, creating the log directory first
+// when requested
+func logFile(opts *config.FileOptions, tmpl *files.Template, t time.Time) (*os.File, error) {
+	if len(opts.LogsDir) == 0 {
+		return nil, fmt.Errorf("no log directory configured")
+	}
+
+	if opts.CreateDirs {
+		dirMode := opts.DirMode
+		if dirMode == 0 {
+			dirMode = 0755
 		}
-	} else {
-		return nil
+		if err := files.MkdirAll(opts.LogsDir, dirMode); err != nil {
+			return nil, err
+		}
+	} else if !files.DirExists(opts.LogsDir) {
+		return nil, fmt.Errorf("log directory [ %s ] does not exist", opts.LogsDir)
+	}
+
+	gid := -1
+	if len(opts.Group) != 0 {
+		var err error
+		if gid, err = files.LookupGroup(opts.Group); err != nil {
+			return nil, err
+		}
+	}
+
+	fileMode := opts.FileMode
+	if fileMode == 0 {
+		fileMode = 0600
 	}
+
+	return files.OpenLog(filepath.Join(opts.LogsDir, tmpl.Format(opts.FileName, t)), fileMode, gid)
 }
 
+// Stop removes the rotation jobs of the logger from its scheduler, and stops
+// the scheduler unless it is shared with other loggers, as well as the
+// watchers of the logger. A stopped logger keeps writing but never rotates
+// again, even when reconfigured.
 func (l *Logger) Stop() {
-	if l.cron != nil {
-		l.cron.Stop()
+	l.mu.Lock()
+	scheduler, own, jobs, watchers := l.scheduler, l.ownScheduler, l.jobs, l.watchers
+	l.stopped = true
+	l.scheduler, l.ownScheduler, l.jobs, l.watchers = nil, false, nil, nil
+	l.mu.Unlock()
+
+	for _, w := range watchers {
+		w.Stop()
+	}
+	if scheduler != nil {
+		scheduler.Remove(jobs...)
+		if own {
+			scheduler.Stop()
+		}
+	}
+}
+
+// now returns the current time of the logger clock in the logger timezone, or
+// in UTC when requested
+func (l *Logger) now() time.Time {
+	l.mu.RLock()
+	clk, location, utc := l.clock, l.timeZone, l.utc
+	l.mu.RUnlock()
+	if utc {
+		return clk.Now().UTC()
 	}
+	return clk.Now().In(location)
 }
 
+// GetLogFile returns the log file currently written, nil without one
 func (l *Logger) GetLogFile() *os.File {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
 	return l.logFile
 }
 
 // IsDebug check the state of debugging output
 func (l *Logger) IsDebug() bool {
+	return l.IsEnabled(Debug)
+}
+
+// IsEnabled check whether records at level are written
+func (l *Logger) IsEnabled(level MessageType) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return level <= l.level
+}
+
+// Level returns the least severe level written by the logger
+func (l *Logger) Level() MessageType {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
-	return l.debug
+	return l.level
+}
+
+// isJSON check whether records are written as JSON
+func (l *Logger) isJSON() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.json
 }
 
 // IsQuiet check for quiet state
@@ -353,195 +574,222 @@ func (l *Logger) IsQuiet() bool {
 
 // Output print the actual value
 func (l *Logger) Output(depth int, prefix Prefix, data Message) error {
+	return l.output(depth+1, nil, prefix, data)
+}
+
+// output prints data and hands rec, when given, to the registered hooks
+func (l *Logger) output(depth int, rec *Record, prefix Prefix, data Message) error {
 	// Check if quiet is requested, and try to return no error and be quiet
 	if l.IsQuiet() {
 		return nil
 	}
 	// Get current time
-	now := time.Now().In(l.timeZone)
-	// Temporary storage for file and line tracing
-	var file string
-	var line int
-	var fn string
+	now := l.now()
+	// Only build records when somebody listens for them or they are
+	// written as JSON or with a layout
+	hooks := l.getHooks()
+	jsonFormat := l.isJSON()
+	lineLayout := l.getLayout()
+	var errs []ErrorInfo
+	if rec != nil {
+		errs = rec.Errors
+	}
+	var level string
+	if len(hooks) == 0 && !jsonFormat && lineLayout == nil {
+		rec = nil
+	} else if rec == nil && (jsonFormat || lineLayout != nil) {
+		level = prefixLevel(prefix)
+		rec = &Record{Logger: l.name, Message: strings.TrimSuffix(string(data.Plain), "\n")}
+		rec.Level, _ = ParseLevel(level)
+	} else if rec != nil {
+		level = rec.Level.Name()
+	}
+	// Caller info and its cached symbols
+	var caller Caller
+	var callerInfo *frame
 	// Check if the specified prefix needs to be included with file logging
-	if prefix.File {
-		var ok bool
-		var pc uintptr
-
-		// Get the caller filename and line
-		if pc, file, line, ok = runtime.Caller(depth + 1); !ok {
-			file = "<unknown file>"
-			fn = "<unknown function>"
-			line = 0
-		} else {
-			file = filepath.Base(file)
-			fn = runtime.FuncForPC(pc).Name()
+	if prefix.File || rec != nil {
+		caller, callerInfo = callerFrame(depth + 1 + l.callerSkip)
+	}
+	// Capture the stack of severe records
+	var stack []Caller
+	if policy := l.getStackPolicy(); policy != nil {
+		severe := rec != nil && rec.Level <= policy.level
+		if rec == nil {
+			prefixed, err := ParseLevel(prefixLevel(prefix))
+			severe = err == nil && prefixed <= policy.level
+		}
+		if severe {
+			stack = policy.capture(depth + 1 + l.callerSkip)
+		}
+	}
+	// Hand the record to the hooks before taking the lock, so hooks are free
+	// to log themselves
+	if rec != nil {
+		rec.Time = now
+		rec.Caller = caller
+		rec.Stack = stack
+		for _, h := range hooks {
+			h.Fire(*rec)
 		}
 	}
 	// Acquire exclusive access to the shared buffer
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	// Structured output skips the text layout altogether
+	if jsonFormat {
+		l.noColorBuf.Reset()
+		appendJSON(&l.noColorBuf.Buffer, rec, level, prefix.File)
+		_, err := l.out.writeAll(l.name, l.noColorBuf.Buffer)
+		return err
+	}
+	// Forced color mode wins over terminal detection
+	color := l.color
+	switch l.colorMode {
+	case colorAlways:
+		color = true
+	case colorNever:
+		color = false
+	}
 	// Reset buffer so it start from the begining
 	l.colorBuf.Reset()
 	l.noColorBuf.Reset()
+	// A custom layout replaces the fixed one below
+	if lineLayout != nil {
+		l.appendLayout(lineLayout, color, rec, callerInfo, prefix, data)
+		l.appendDetails(color, errs, stack)
+		return l.flush()
+	}
 	// Write prefix to the buffer
 
 	l.noColorBuf.Append(prefix.Plain)
-	if l.color {
+	if color {
 		l.colorBuf.Append(prefix.Color)
 	} else {
 		l.colorBuf.Append(prefix.Plain)
 	}
 	// Check if the log require timestamping
 	if l.timestamp {
-		// Print timestamp color if color enabled
-		if l.color {
-			l.colorBuf.Blue()
-		}
-		// Print date and time
-
-		l.colorBuf.Append([]byte(now.Format(l.timeFormat)))
-		l.noColorBuf.Append([]byte(now.Format(l.timeFormat)))
-
-		l.colorBuf.AppendByte(' ')
+		// Print date and time in the timestamp color if color enabled
+		start := len(l.noColorBuf.Buffer)
+		l.noColorBuf.Buffer = l.timeStamp.appendTo(l.noColorBuf.Buffer, now)
 		l.noColorBuf.AppendByte(' ')
-
-		hour, minutes, sec := now.Clock()
-
-		l.colorBuf.AppendInt(hour, 2)
-		l.noColorBuf.AppendInt(hour, 2)
-
-		l.colorBuf.AppendByte(':')
-		l.noColorBuf.AppendByte(':')
-
-		l.colorBuf.AppendInt(minutes, 2)
-		l.noColorBuf.AppendInt(minutes, 2)
-
-		l.colorBuf.AppendByte(':')
-		l.noColorBuf.AppendByte(':')
-
-		l.colorBuf.AppendInt(sec, 2)
-		l.noColorBuf.AppendInt(sec, 2)
-
+		l.appendPainted(color, l.palette.timeStamp, start)
+	}
+	// Add the logger name
+	if len(l.name) != 0 {
+		l.appendName(color, l.name, l.name)
 		l.colorBuf.AppendByte(' ')
 		l.noColorBuf.AppendByte(' ')
-
-		// Print reset color if color enabled
-		if l.color {
-			l.colorBuf.Off()
-		}
 	}
 	// Add caller filename and line if enabled
 	if prefix.File {
-		// Print color start if enabled
-		if l.color {
-			l.colorBuf.Orange()
-		}
-		// Print filename and line
-		l.colorBuf.Append([]byte(fn))
-		l.noColorBuf.Append([]byte(fn))
-
-		l.colorBuf.AppendByte(':')
-		l.noColorBuf.AppendByte(':')
-
-		l.colorBuf.Append([]byte(file))
-		l.noColorBuf.Append([]byte(file))
-
-		l.colorBuf.AppendByte(':')
-		l.noColorBuf.AppendByte(':')
-
-		l.colorBuf.AppendInt(line, 0)
-		l.noColorBuf.AppendInt(line, 0)
-
-		l.colorBuf.AppendByte(' ')
+		// Print function, filename and line in the caller color if enabled
+		start := len(l.noColorBuf.Buffer)
+		appendCaller(&l.noColorBuf.Buffer, l.callerFormat, caller, callerInfo)
 		l.noColorBuf.AppendByte(' ')
-
-		// Print color stop
-		if l.color {
-			l.colorBuf.Off()
-		}
+		l.appendPainted(color, l.palette.caller, start)
 	}
 
 	l.noColorBuf.Append(data.Plain)
 	// Print the actual string data from caller
-	if l.color {
+	if color {
 		l.colorBuf.Append(data.Color)
 	} else {
 		l.colorBuf.Append(data.Plain)
 	}
+	// Print the error details and stack trace below the line
+	l.appendDetails(color, errs, stack)
+
+	return l.flush()
+}
 
-	// Flush buffer to output
-	_, err := l.out.Write(l.colorBuf.Buffer, l.noColorBuf.Buffer)
+// flush writes the buffers to the output, the colored one to the writers
+// getting color in the color mode. The caller holds the lock.
+func (l *Logger) flush() error {
+	_, err := l.out.writeColored(l.name, l.colorMode, l.colored, l.colorBuf.Buffer, l.noColorBuf.Buffer)
 	return err
 }
 
 // Fatal print fatal coloredMessage to output and quit the application with status 1
 func (l *Logger) Fatal(v ...interface{}) {
-	l.Output(1, FatalPrefix, l.coloredMessage(Fatal, fmt.Sprintln(v...)))
+	l.log(Fatal, fmt.Sprintln(v...), v)
 	os.Exit(1)
 }
 
 // Fatalf print formatted fatal coloredMessage to output and quit the application
 // with status 1
 func (l *Logger) Fatalf(format string, v ...interface{}) {
-	l.Output(1, FatalPrefix, l.coloredMessage(Fatal, fmt.Sprintf(format, v...)))
+	l.log(Fatal, fmt.Sprintf(format, v...), v)
 	os.Exit(1)
 }
 
 // Error print error coloredMessage to output
 func (l *Logger) Error(v ...interface{}) {
-	l.Output(1, ErrorPrefix, l.coloredMessage(Error, fmt.Sprintln(v...)))
+	if l.enabled(Error) {
+		l.log(Error, fmt.Sprintln(v...), v)
+	}
 }
 
 // Errorf print formatted error coloredMessage to output
 func (l *Logger) Errorf(format string, v ...interface{}) {
-	l.Output(1, ErrorPrefix, l.coloredMessage(Error, fmt.Sprintf(format, v...)))
+	if l.enabled(Error) {
+		l.log(Error, fmt.Sprintf(format, v...), v)
+	}
 }
 
 // Warn print warning coloredMessage to output
 func (l *Logger) Warn(v ...interface{}) {
-	l.Output(1, WarnPrefix, l.coloredMessage(Warn, fmt.Sprintln(v...)))
+	if l.enabled(Warn) {
+		l.log(Warn, fmt.Sprintln(v...), v)
+	}
 }
 
 // Warnf print formatted warning coloredMessage to output
 func (l *Logger) Warnf(format string, v ...interface{}) {
-	l.Output(1, WarnPrefix, l.coloredMessage(Warn, fmt.Sprintf(format, v...)))
+	if l.enabled(Warn) {
+		l.log(Warn, fmt.Sprintf(format, v...), v)
+	}
 }
 
 // Info print informational coloredMessage to output
 func (l *Logger) Info(v ...interface{}) {
-	l.Output(1, InfoPrefix, l.coloredMessage(Info, fmt.Sprintln(v...)))
+	if l.enabled(Info) {
+		l.log(Info, fmt.Sprintln(v...), v)
+	}
 }
 
 // Infof print formatted informational coloredMessage to output
 func (l *Logger) Infof(format string, v ...interface{}) {
-	l.Output(1, InfoPrefix, l.coloredMessage(Info, fmt.Sprintf(format, v...)))
+	if l.enabled(Info) {
+		l.log(Info, fmt.Sprintf(format, v...), v)
+	}
 }
 
 // Debug print debug coloredMessage to output if debug output enabled
 func (l *Logger) Debug(v ...interface{}) {
-	if l.IsDebug() {
-		l.Output(1, DebugPrefix, l.coloredMessage(Debug, fmt.Sprintln(v...)))
+	if l.enabled(Debug) {
+		l.log(Debug, fmt.Sprintln(v...), v)
 	}
 }
 
 // Debugf print formatted debug coloredMessage to output if debug output enabled
 func (l *Logger) Debugf(format string, v ...interface{}) {
-	if l.IsDebug() {
-		l.Output(1, DebugPrefix, l.coloredMessage(Debug, fmt.Sprintf(format, v...)))
+	if l.enabled(Debug) {
+		l.log(Debug, fmt.Sprintf(format, v...), v)
 	}
 }
 
 // Trace print trace coloredMessage to output if debug output enabled
 func (l *Logger) Trace(v ...interface{}) {
-	if l.IsDebug() {
-		l.Output(1, TracePrefix, l.coloredMessage(Trace, fmt.Sprintln(v...)))
+	if l.enabled(Trace) {
+		l.log(Trace, fmt.Sprintln(v...), v)
 	}
 }
 
 // Tracef print formatted trace coloredMessage to output if debug output enabled
 func (l *Logger) Tracef(format string, v ...interface{}) {
-	if l.IsDebug() {
-		l.Output(1, TracePrefix, l.coloredMessage(Trace, fmt.Sprintf(format, v...)))
+	if l.enabled(Trace) {
+		l.log(Trace, fmt.Sprintf(format, v...), v)
 	}
 }
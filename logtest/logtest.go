@@ -0,0 +1,131 @@
+// Testing helpers for code built on the go-log library
+
+package logtest
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/config"
+)
+
+// Recorder is a log.Hook keeping every record in memory
+type Recorder struct {
+	mu      sync.Mutex
+	records []log.Record
+}
+
+// NewRecorder returns an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// New returns a logger with debug output enabled that writes through tb.Log,
+// so its output only shows up for failing tests, along with the recorder
+// capturing its records
+func New(tb testing.TB) (*log.Logger, *Recorder) {
+	logger := log.New(log.NewFdWriters(NewWriter(tb)), config.LogOptions{Debug: true})
+	rec := NewRecorder()
+	logger.AddHook(rec)
+	return logger, rec
+}
+
+// Fire records rec
+func (r *Recorder) Fire(rec log.Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, rec)
+}
+
+// Records returns a copy of the records captured so far
+func (r *Recorder) Records() []log.Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]log.Record(nil), r.records...)
+}
+
+// Reset drops the records captured so far
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = nil
+}
+
+// AssertLogged fails the test unless a record at level contains substring
+// in its message
+func (r *Recorder) AssertLogged(tb testing.TB, level log.MessageType, substring string) bool {
+	tb.Helper()
+	for _, rec := range r.Records() {
+		if rec.Level == level && strings.Contains(rec.Message, substring) {
+			return true
+		}
+	}
+	tb.Errorf("expected a %s record containing %q, got:\n%s", level, substring, r)
+	return false
+}
+
+// AssertNotLogged fails the test when a record at level contains substring
+// in its message
+func (r *Recorder) AssertNotLogged(tb testing.TB, level log.MessageType, substring string) bool {
+	tb.Helper()
+	for _, rec := range r.Records() {
+		if rec.Level == level && strings.Contains(rec.Message, substring) {
+			tb.Errorf("unexpected %s record %q logged at %s:%d", level, rec.Message, rec.Caller.File, rec.Caller.Line)
+			return false
+		}
+	}
+	return true
+}
+
+// AssertNoErrors fails the test when an error or fatal record was captured
+func (r *Recorder) AssertNoErrors(tb testing.TB) bool {
+	tb.Helper()
+	ok := true
+	for _, rec := range r.Records() {
+		if rec.Level == log.Error || rec.Level == log.Fatal {
+			tb.Errorf("unexpected %s record %q logged at %s:%d", rec.Level, rec.Message, rec.Caller.File, rec.Caller.Line)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// String lists the captured records one per line
+func (r *Recorder) String() string {
+	var b strings.Builder
+	for _, rec := range r.Records() {
+		b.WriteString("\t[")
+		b.WriteString(rec.Level.String())
+		b.WriteString("] ")
+		b.WriteString(rec.Message)
+		b.WriteString(rec.Fields.String())
+		b.WriteByte('\n')
+	}
+	if b.Len() == 0 {
+		return "\t<no records>\n"
+	}
+	return b.String()
+}
+
+// Writer is a log.FdWriter routing log output through testing.TB.Log
+type Writer struct {
+	tb testing.TB
+}
+
+// NewWriter returns a writer logging through tb
+func NewWriter(tb testing.TB) *Writer {
+	return &Writer{tb: tb}
+}
+
+// Write logs p without its trailing newline
+func (w *Writer) Write(p []byte) (int, error) {
+	w.tb.Log(strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
+// Fd returns an invalid descriptor, so the output is never colored
+func (w *Writer) Fd() uintptr {
+	return ^uintptr(0)
+}
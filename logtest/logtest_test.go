@@ -0,0 +1,46 @@
+// Test file for logtest
+
+package logtest
+
+import (
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRecorder(t *testing.T) {
+	Convey("Given a recording logger", t, func() {
+		logger, rec := New(t)
+
+		Convey("When records are logged with fields", func() {
+			logger.WithFields(log.Fields{"user": "alice"}).Warnf("quota at %d%%", 95)
+			logger.Debug("cache miss")
+
+			Convey("It should capture level, message, fields and caller", func() {
+				records := rec.Records()
+				So(len(records), ShouldEqual, 2)
+				So(records[0].Level, ShouldEqual, log.Warn)
+				So(records[0].Message, ShouldEqual, "quota at 95%")
+				So(records[0].Fields["user"], ShouldEqual, "alice")
+				So(records[0].Caller.File, ShouldEndWith, "logtest_test.go")
+			})
+
+			Convey("It should pass the assertions", func() {
+				So(rec.AssertLogged(t, log.Warn, "quota"), ShouldBeTrue)
+				So(rec.AssertLogged(t, log.Debug, "miss"), ShouldBeTrue)
+				So(rec.AssertNotLogged(t, log.Info, "quota"), ShouldBeTrue)
+				So(rec.AssertNoErrors(t), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the recorder is reset", func() {
+			logger.Info("before reset")
+			rec.Reset()
+
+			Convey("It should have no records", func() {
+				So(rec.Records(), ShouldBeEmpty)
+			})
+		})
+	})
+}
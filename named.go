@@ -0,0 +1,82 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import "strings"
+
+// Named returns a logger sharing the output and settings of l named after
+// name below the name of l, e.g. "db.pool" for logger.Named("db").Named("pool")
+func (l *Logger) Named(name string) *Logger {
+	derived := *l
+	if len(l.name) != 0 {
+		derived.name = l.name + "." + name
+	} else {
+		derived.name = name
+	}
+	return &derived
+}
+
+// Name returns the dotted name of the logger, empty for unnamed loggers
+func (l *Logger) Name() string {
+	return l.name
+}
+
+// SetLoggerLevel override the logger level for the logger with the given
+// name and the loggers named below it
+func (l *Logger) SetLoggerLevel(name string, level MessageType) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	levels := make(map[string]MessageType, len(l.loggerLevels)+1)
+	for k, v := range l.loggerLevels {
+		levels[k] = v
+	}
+	levels[name] = level
+	l.loggerLevels = levels
+}
+
+// ClearLoggerLevel remove the level override of a logger name
+func (l *Logger) ClearLoggerLevel(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	levels := make(map[string]MessageType, len(l.loggerLevels))
+	for k, v := range l.loggerLevels {
+		if k != name {
+			levels[k] = v
+		}
+	}
+	l.loggerLevels = levels
+}
+
+// LoggerLevels returns a copy of the per logger name level overrides
+func (l *Logger) LoggerLevels() map[string]MessageType {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	levels := make(map[string]MessageType, len(l.loggerLevels))
+	for k, v := range l.loggerLevels {
+		levels[k] = v
+	}
+	return levels
+}
+
+// loggerLevel finds the override of the longest name matching name
+func loggerLevel(levels map[string]MessageType, name string) (MessageType, bool) {
+	for {
+		if level, ok := levels[name]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return 0, false
+		}
+		name = name[:i]
+	}
+}
+
+// appendName append text, the logger name as padded by the layout, to both
+// buffers, in the color of name when colored. The caller holds the lock.
+func (l *Logger) appendName(color bool, name, text string) {
+	start := len(l.noColorBuf.Buffer)
+	l.noColorBuf.AppendString(text)
+	l.appendPainted(color, l.palette.name(name), start)
+}
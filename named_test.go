@@ -0,0 +1,94 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the named loggers
+
+package log_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNamed(t *testing.T) {
+	Convey("Given a named child logger", t, func() {
+		out := &pipe{}
+		logger := log.New(log.NewFdWriters(out), config.LogOptions{})
+		pool := logger.Named("db").Named("pool")
+
+		Convey("It should join the names with dots", func() {
+			So(pool.Name(), ShouldEqual, "db.pool")
+			So(logger.Name(), ShouldBeEmpty)
+		})
+
+		Convey("When it logs", func() {
+			pool.Info("connected")
+
+			Convey("It should print the name in front of the message", func() {
+				So(out.String(), ShouldEqual, "[INFO]  db.pool connected\n")
+			})
+		})
+
+		Convey("When the level of a parent name is overridden", func() {
+			logger.SetLoggerLevel("db", log.Warn)
+			pool.Info("dropped")
+			logger.Named("http").Info("kept")
+
+			Convey("It should apply to the loggers below it only", func() {
+				So(out.String(), ShouldEqual, "[INFO]  http kept\n")
+				So(logger.LoggerLevels(), ShouldResemble, map[string]log.MessageType{"db": log.Warn})
+			})
+		})
+
+		Convey("When color is forced on", func() {
+			pool.WithColor().Info("first")
+			first := out.String()
+			out.Reset()
+			pool.Info("second")
+
+			Convey("It should paint the name in the same color every time", func() {
+				nameColor := func(line string) string {
+					i := strings.Index(line, "db.pool")
+					return line[i-len("\033[0;32m") : i]
+				}
+				So(nameColor(first), ShouldStartWith, "\033[0;3")
+				So(nameColor(out.String()), ShouldEqual, nameColor(first))
+			})
+		})
+	})
+
+	Convey("Given a named JSON logger", t, func() {
+		out := &pipe{}
+		logger, _ := log.NewLogger(log.WithOutput(out), log.WithJSON())
+		logger.Named("worker").Warn("slow")
+
+		Convey("It should write the name as logger", func() {
+			var rec map[string]interface{}
+			So(json.Unmarshal(out.Bytes(), &rec), ShouldBeNil)
+			So(rec["logger"], ShouldEqual, "worker")
+		})
+	})
+	Convey("Given writers routed by logger name", t, func() {
+		rest, db := &pipe{}, &pipe{}
+		logger := log.New(log.NewFdWriters(
+			log.ExceptNames(rest, "db"),
+			log.ForNames(db, "db"),
+		), config.LogOptions{})
+
+		Convey("When loggers with and without the name log", func() {
+			logger.Named("db").Named("pool").Info("connected")
+			logger.Named("dbx").Info("other")
+			logger.Info("root")
+
+			Convey("It should write each line to the writer taking its name", func() {
+				So(db.String(), ShouldEqual, "[INFO]  db.pool connected\n")
+				So(rest.String(), ShouldEqual, "[INFO]  dbx other\n[INFO]  root\n")
+			})
+		})
+	})
+}
@@ -0,0 +1,216 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"os"
+
+	"github.com/rish1988/go-log/clock"
+	"github.com/rish1988/go-log/config"
+)
+
+// Option configures a logger built by NewLogger
+type Option func(*settings)
+
+// settings collect the writers and options for NewLogger
+type settings struct {
+	out     FdWriters
+	options config.LogOptions
+}
+
+// NewLogger returns new Logger instance configured by opts, writing to stderr
+// unless WithOutput is given. Options are applied in order, so later options
+// win. Like Open it fails when the resulting options are invalid.
+func NewLogger(opts ...Option) (*Logger, error) {
+	var s settings
+	for _, opt := range opts {
+		opt(&s)
+	}
+	if len(s.out) == 0 {
+		s.out = NewFdWriters(os.Stderr)
+	}
+	return Open(s.out, s.options)
+}
+
+// WithOptions start from existing options, e.g. loaded with config.Load
+func WithOptions(options config.LogOptions) Option {
+	return func(s *settings) {
+		s.options = options
+	}
+}
+
+// WithOutput add writers to write the log to
+func WithOutput(writers ...FdWriter) Option {
+	return func(s *settings) {
+		s.out = append(s.out, writers...)
+	}
+}
+
+// WithLevel set the least severe level to write
+func WithLevel(level MessageType) Option {
+	return func(s *settings) {
+		s.options.Level = level.Name()
+	}
+}
+
+// WithTimestamp prefix every line with date and time
+func WithTimestamp() Option {
+	return func(s *settings) {
+		s.options.TimeStamp = true
+	}
+}
+
+// WithTimeFormat set the timestamp format, a Go time layout or one of the
+// presets such as "rfc3339" or "unixmilli"
+func WithTimeFormat(format string) Option {
+	return func(s *settings) {
+		s.options.TimeStamp = true
+		s.options.TimeFormat = format
+	}
+}
+
+// WithTimePrecision set the sub-second precision, "s", "ms", "us" or "ns"
+func WithTimePrecision(precision string) Option {
+	return func(s *settings) {
+		s.options.TimeStamp = true
+		s.options.TimePrecision = precision
+	}
+}
+
+// WithUTC print timestamps in UTC
+func WithUTC() Option {
+	return func(s *settings) {
+		s.options.UTC = true
+	}
+}
+
+// WithJSON write one JSON object per line instead of text
+func WithJSON() Option {
+	return func(s *settings) {
+		s.options.Format = "json"
+	}
+}
+
+// WithLayout write text lines following pattern, see the layout package for
+// the placeholders
+func WithLayout(pattern string) Option {
+	return func(s *settings) {
+		s.options.Layout = pattern
+	}
+}
+
+// WithColors set the colors used per level
+func WithColors(colors config.ColorOptions) Option {
+	return func(s *settings) {
+		s.options.ColorOptions = colors
+	}
+}
+
+// WithTheme select the color theme by name, e.g. "solarized", see
+// colorful.ThemeByName. Level colors set with WithColors win over the theme.
+func WithTheme(name string) Option {
+	return func(s *settings) {
+		s.options.ColorOptions.Theme = name
+	}
+}
+
+// WithPrefix override the prefix printed in front of level
+func WithPrefix(level MessageType, prefix config.PrefixOptions) Option {
+	return func(s *settings) {
+		prefixes := make(map[string]config.PrefixOptions, len(s.options.Prefixes)+1)
+		for k, v := range s.options.Prefixes {
+			prefixes[k] = v
+		}
+		prefixes[level.Name()] = prefix
+		s.options.Prefixes = prefixes
+	}
+}
+
+// WithCaller turn the caller info of level on or off
+func WithCaller(level MessageType, enabled bool) Option {
+	return func(s *settings) {
+		prefix := s.options.Prefixes[level.Name()]
+		prefix.Caller = &enabled
+		WithPrefix(level, prefix)(s)
+	}
+}
+
+// WithCallerFormat set how the caller info is printed, e.g. "short" or
+// "relative"
+func WithCallerFormat(format string) Option {
+	return func(s *settings) {
+		s.options.CallerFormat = format
+	}
+}
+
+// WithStackTrace attach stack traces to records at level and above. Frames
+// of the runtime and standard library are dropped when hideRuntime is set,
+// frames of packages matching hidePackages always.
+func WithStackTrace(level MessageType, hideRuntime bool, hidePackages ...string) Option {
+	return func(s *settings) {
+		s.options.Stack = &config.StackOptions{
+			Level:        level.Name(),
+			HideRuntime:  hideRuntime,
+			HidePackages: hidePackages,
+		}
+	}
+}
+
+// WithQuiet start the logger in quiet state
+func WithQuiet() Option {
+	return func(s *settings) {
+		s.options.Quiet = true
+	}
+}
+
+// WithClock set the time source for timestamps, file names and rotation
+func WithClock(c clock.Clock) Option {
+	return func(s *settings) {
+		s.options.Clock = c
+	}
+}
+
+// WithFile also write the log to files named after name inside dir
+func WithFile(dir, name string) Option {
+	return func(s *settings) {
+		file := s.fileOptions()
+		file.LogsDir = dir
+		file.FileName = name
+	}
+}
+
+// WithFileOptions adjust the remaining file settings, e.g. the file name
+// template, symlink or permissions
+func WithFileOptions(configure func(*config.FileOptions)) Option {
+	return func(s *settings) {
+		configure(s.fileOptions())
+	}
+}
+
+// WithRotation rotate the log file on the cron schedule interval and keep at
+// most maxFiles files
+func WithRotation(interval string, maxFiles int) Option {
+	return func(s *settings) {
+		file := s.fileOptions()
+		if file.RotationPolicyOptions == nil {
+			file.RotationPolicyOptions = &config.RotationPolicyOptions{}
+		} else {
+			rotation := *file.RotationPolicyOptions
+			file.RotationPolicyOptions = &rotation
+		}
+		file.RotationInterval = interval
+		file.MaxFiles = maxFiles
+	}
+}
+
+// fileOptions returns file options owned by the settings, copying the ones
+// shared with options passed to WithOptions
+func (s *settings) fileOptions() *config.FileOptions {
+	file := &config.FileOptions{}
+	if s.options.FileOptions != nil {
+		*file = *s.options.FileOptions
+	}
+	s.options.FileOptions = file
+	return file
+}
@@ -0,0 +1,76 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the functional options
+
+package log_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewLogger(t *testing.T) {
+	Convey("Given a logger built from options writing JSON", t, func() {
+		out := &pipe{}
+		logger, err := log.NewLogger(
+			log.WithOutput(out),
+			log.WithLevel(log.Warn),
+			log.WithJSON(),
+		)
+		So(err, ShouldBeNil)
+
+		Convey("When records are logged with fields", func() {
+			logger.Info("dropped")
+			logger.WithFields(log.Fields{"attempt": 3, "level": "shadowed"}).Warn("retrying")
+
+			Convey("It should write one JSON object per record above the level", func() {
+				var rec map[string]interface{}
+				So(json.Unmarshal(out.Bytes(), &rec), ShouldBeNil)
+				So(rec["level"], ShouldEqual, "warn")
+				So(rec["message"], ShouldEqual, "retrying")
+				So(rec["attempt"], ShouldEqual, 3)
+				So(rec["fields.level"], ShouldEqual, "shadowed")
+			})
+		})
+	})
+
+	Convey("Given file and rotation options", t, func() {
+		dir := t.TempDir()
+		logger, err := log.NewLogger(
+			log.WithOutput(&pipe{}),
+			log.WithFile(dir, "app"),
+			log.WithRotation("@hourly", 24),
+			log.WithFileOptions(func(f *config.FileOptions) {
+				f.FileNameTemplate = "{name}-{time:2006-01-02T15}.log"
+				f.LinkName = "app.log"
+			}),
+		)
+		So(err, ShouldBeNil)
+		defer logger.Stop()
+
+		Convey("When a record is logged", func() {
+			logger.Info("to the file")
+
+			Convey("It should be written to the templated file", func() {
+				data, err := os.ReadFile(filepath.Join(dir, "app.log"))
+				So(err, ShouldBeNil)
+				So(string(data), ShouldEqual, "[INFO]  to the file\n")
+			})
+		})
+	})
+
+	Convey("Given an invalid rotation interval", t, func() {
+		_, err := log.NewLogger(log.WithFile(t.TempDir(), "app"), log.WithRotation("hourly", 24))
+
+		Convey("It should fail", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,97 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"github.com/rish1988/go-log/colorful"
+	"github.com/rish1988/go-log/config"
+)
+
+// defaultPrefixes returns the package level prefixes, indexed by level
+func defaultPrefixes() [Trace + 1]Prefix {
+	return [Trace + 1]Prefix{
+		Fatal: FatalPrefix,
+		Error: ErrorPrefix,
+		Warn:  WarnPrefix,
+		Info:  InfoPrefix,
+		Debug: DebugPrefix,
+		Trace: TracePrefix,
+	}
+}
+
+// levelColor returns the color configured for level, or nil for the default
+func levelColor(colors config.ColorOptions, level MessageType) colorful.Color {
+	switch level {
+	case Fatal:
+		return colors.Fatal
+	case Error:
+		return colors.Error
+	case Warn:
+		return colors.Warn
+	case Info:
+		return colors.Info
+	case Debug:
+		return colors.Debug
+	case Trace:
+		return colors.Trace
+	}
+	return nil
+}
+
+// prefixesOf builds the prefixes of a logger from the package level prefixes,
+// the theme, the level colors and the prefix overrides of the options
+func prefixesOf(options config.LogOptions) [Trace + 1]Prefix {
+	prefixes := defaultPrefixes()
+	themed := paletteOf(options)
+	for level := Fatal; level <= Trace; level++ {
+		p := &prefixes[level]
+		color := levelColor(options.ColorOptions, level)
+		if color == nil && len(options.ColorOptions.Theme) != 0 {
+			color = themed.level(level)
+		}
+
+		if override, ok := options.Prefixes[level.Name()]; ok {
+			if len(override.Text) != 0 {
+				p.Plain = []byte(override.Text)
+				// The default colored text was built for the old text
+				if color == nil {
+					color = themed.level(level)
+				}
+			}
+			if override.Color != nil {
+				color = override.Color
+			}
+			if override.Caller != nil {
+				p.File = *override.Caller
+			}
+		}
+
+		if color != nil {
+			p.Color = color(p.Plain)
+		}
+	}
+	return prefixes
+}
+
+// Prefix returns the prefix the logger prints in front of level
+func (l *Logger) Prefix(level MessageType) Prefix {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if level < Fatal || level > Trace {
+		return Prefix{}
+	}
+	return l.prefixes[level]
+}
+
+// SetPrefix replace the prefix the logger prints in front of level. Loggers
+// derived from l with Named, WithFields or WithCallerSkip share the prefixes
+// of l and change along; other loggers and the package level prefixes are
+// left alone.
+func (l *Logger) SetPrefix(level MessageType, prefix Prefix) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if level >= Fatal && level <= Trace {
+		l.prefixes[level] = prefix
+	}
+}
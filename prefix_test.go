@@ -0,0 +1,77 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the per logger prefixes
+
+package log_test
+
+import (
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/colorful"
+	"github.com/rish1988/go-log/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPrefixes(t *testing.T) {
+	Convey("Given two loggers with different error colors", t, func() {
+		blueOut, cyanOut := &pipe{}, &pipe{}
+		blue := log.New(log.NewFdWriters(blueOut), config.LogOptions{
+			ColorOptions: config.ColorOptions{Color: true, Error: colorful.Blue},
+		})
+		cyan := log.New(log.NewFdWriters(cyanOut), config.LogOptions{
+			ColorOptions: config.ColorOptions{Color: true, Error: colorful.Cyan},
+		})
+		global := log.ErrorPrefix.Color
+
+		Convey("When both log an error", func() {
+			blue.Error("boom")
+			cyan.Error("boom")
+
+			Convey("It should color each prefix with its own logger color", func() {
+				So(blueOut.String(), ShouldStartWith, string(colorful.Blue([]byte("[ERROR] "))))
+				So(cyanOut.String(), ShouldStartWith, string(colorful.Cyan([]byte("[ERROR] "))))
+			})
+
+			Convey("It should leave the package level prefix alone", func() {
+				So(log.ErrorPrefix.Color, ShouldResemble, global)
+			})
+		})
+	})
+
+	Convey("Given a logger with a custom warn prefix", t, func() {
+		out := &pipe{}
+		noCaller := false
+		logger, err := log.NewLogger(
+			log.WithOutput(out),
+			log.WithPrefix(log.Warn, config.PrefixOptions{Text: "W ", Caller: &noCaller}),
+			log.WithPrefix(log.Error, config.PrefixOptions{Text: "E "}),
+		)
+		So(err, ShouldBeNil)
+
+		Convey("When it logs a warning and an error", func() {
+			logger.Warn("careful")
+			logger.Error("broken")
+
+			Convey("It should print the custom prefix text", func() {
+				So(out.String(), ShouldStartWith, "W careful\nE ")
+				So(out.String(), ShouldEndWith, " broken\n")
+			})
+		})
+	})
+	Convey("Given a logger and a named logger derived from it", t, func() {
+		out := &pipe{}
+		logger, _ := log.NewLogger(log.WithOutput(out))
+		child := logger.Named("db")
+
+		Convey("When the prefix is replaced on the child", func() {
+			child.SetPrefix(log.Info, log.Prefix{Plain: []byte("I ")})
+			logger.Info("shared")
+
+			Convey("It should change the prefix of the parent as well", func() {
+				So(out.String(), ShouldEqual, "I shared\n")
+			})
+		})
+	})
+}
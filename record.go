@@ -0,0 +1,157 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rish1988/go-log/colorful"
+	"github.com/rish1988/go-log/config"
+	"github.com/rish1988/go-log/layout"
+)
+
+// Fields are key and value pairs attached to every record of a logger
+type Fields map[string]interface{}
+
+// Caller identify the source location a record was logged from
+type Caller struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// Record is a single log entry as handed to hooks
+type Record struct {
+	Time  time.Time
+	Level MessageType
+	// Logger is the name of the logger, empty for unnamed loggers
+	Logger  string
+	Message string
+	Fields  Fields
+	Caller  Caller
+	// Stack is the stack trace of records at or above the stack trace
+	// level, innermost frame first
+	Stack []Caller
+	// Errors describe the errors among the logged values
+	Errors []ErrorInfo
+}
+
+// Hook receives every record written by a logger
+type Hook interface {
+	Fire(rec Record)
+}
+
+// String returns the level name as printed in the prefix
+func (m MessageType) String() string {
+	switch m {
+	case Fatal:
+		return "FATAL"
+	case Error:
+		return "ERROR"
+	case Warn:
+		return "WARN"
+	case Info:
+		return "INFO"
+	case Debug:
+		return "DEBUG"
+	case Trace:
+		return "TRACE"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(m))
+}
+
+// Name returns the configuration name of the level, e.g. "warn"
+func (m MessageType) Name() string {
+	return config.LevelName(int(m))
+}
+
+// ParseLevel returns the level with the given name, e.g. "warn"
+func ParseLevel(name string) (MessageType, error) {
+	level, err := config.ParseLevel(name)
+	return MessageType(level), err
+}
+
+// AddHook register hook on the logger and every logger derived from it
+func (l *Logger) AddHook(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook)
+}
+
+func (l *Logger) getHooks() []Hook {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.hooks
+}
+
+// WithFields returns a logger sharing the output and settings of l that
+// attach fields, on top of the fields of l, to every record
+func (l *Logger) WithFields(fields Fields) *Logger {
+	merged := make(Fields, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	derived := *l
+	derived.fields = merged
+	return &derived
+}
+
+// log print text at level with the logger prefix and fields, describing the
+// errors among args
+func (l *Logger) log(level MessageType, text string, args []interface{}) {
+	text = strings.TrimSuffix(text, "\n")
+	rec := &Record{
+		Level:   level,
+		Logger:  l.name,
+		Message: text,
+		Fields:  l.fields,
+		Errors:  errorInfos(args),
+	}
+	var fields Fields
+	if !l.layoutHas(layout.Fields) {
+		fields = l.fields
+	}
+	l.output(2, rec, l.Prefix(level), l.coloredMessage(level, text, fields))
+}
+
+// String render the fields as space separated key=value pairs sorted by key
+func (f Fields) String() string {
+	if len(f) == 0 {
+		return ""
+	}
+	var cb colorful.ColorBuffer
+	f.appendTo(&cb, nil, nil)
+	return string(cb.Buffer)
+}
+
+// appendTo append the fields as String renders them to cb, painting the keys
+// and values with the key and value sequences when they are set
+func (f Fields) appendTo(cb *colorful.ColorBuffer, key, value []byte) {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		cb.AppendByte(' ')
+		cb.Append(key)
+		cb.AppendString(k)
+		if len(key) != 0 {
+			cb.Off()
+		}
+		cb.AppendByte('=')
+		cb.Append(value)
+		cb.Buffer = fmt.Appendf(cb.Buffer, "%v", f[k])
+		if len(value) != 0 {
+			cb.Off()
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rish1988/go-log/clock"
+	"github.com/rish1988/go-log/config"
+)
+
+// Reconfigure applies options to the running logger. Level, colors, outputs
+// and rotation policy are swapped in at once, so every line is written with
+// either the old or the new settings. The options are validated first and
+// the current configuration is kept when they are invalid. A nil Clock keeps
+// the current clock.
+func (l *Logger) Reconfigure(options config.LogOptions) error {
+	l.reconfigure.Lock()
+	defer l.reconfigure.Unlock()
+
+	if options.Clock == nil {
+		options.Clock = l.clock
+	}
+
+	if err := options.Validate(); err != nil {
+		return err
+	}
+
+	out := l.baseOut
+	if len(out) == 0 {
+		out = NewFdWriters(os.Stderr)
+	}
+	next := newLogger(out, options)
+	if options.FileOptions != nil {
+		var err error
+		if next, err = getLogger(out, options); err != nil {
+			return err
+		}
+	}
+
+	l.mu.Lock()
+	prevFile := l.logFile
+	prevScheduler, prevOwn, prevJobs := l.scheduler, l.ownScheduler, l.jobs
+	l.color = next.color
+	l.colored = next.colored
+	l.colorMode = next.colorMode
+	l.json = next.json
+	l.layout = next.layout
+	l.out = next.out
+	l.level = next.level
+	l.timestamp = next.timestamp
+	l.quiet = next.quiet
+	l.palette = next.palette
+	l.prefixes = next.prefixes
+	l.logFile = next.logFile
+	l.timeStamp = next.timeStamp
+	l.utc = next.utc
+	l.callerFormat = next.callerFormat
+	l.stack = next.stack
+	l.timeZone = next.timeZone
+	l.clock = next.clock
+	l.options = options
+	l.generation++
+	l.scheduler, l.ownScheduler, l.jobs = nil, false, nil
+	l.mu.Unlock()
+
+	if prevScheduler != nil {
+		prevScheduler.Remove(prevJobs...)
+		if prevOwn {
+			prevScheduler.Stop()
+		}
+	}
+	if prevFile != nil {
+		prevFile.Close()
+	}
+
+	if options.FileOptions != nil {
+		return l.schedule(options)
+	}
+	return nil
+}
+
+// Options returns the options the logger currently runs with
+func (l *Logger) Options() config.LogOptions {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.options
+}
+
+// Watcher polls a configuration file and applies it to a logger whenever its
+// content changes
+type Watcher struct {
+	mu       sync.Mutex
+	once     sync.Once
+	logger   *Logger
+	path     string
+	interval time.Duration
+	clock    clock.Clock
+	content  []byte
+	file     config.File
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Watch starts polling the configuration file at path every interval and
+// reconfigures the logger when it changes. The changed settings are logged
+// at info level; a file that fails to load or validate is reported at error
+// level and the current configuration is kept.
+func (l *Logger) Watch(path string, interval time.Duration) *Watcher {
+	w := &Watcher{
+		logger:   l,
+		path:     path,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	w.content, _ = os.ReadFile(path)
+	w.file, _ = config.LoadFile(path)
+
+	l.mu.Lock()
+	w.clock = l.clock
+	if l.stopped {
+		// Nothing is left to watch for
+		w.once.Do(func() { close(w.stop) })
+	} else {
+		l.watchers = append(l.watchers, w)
+	}
+	l.mu.Unlock()
+
+	go w.run()
+	return w
+}
+
+// Stop ends polling and waits for a running reload to finish. The watcher is
+// stopped along with its logger as well.
+func (w *Watcher) Stop() {
+	w.once.Do(func() {
+		close(w.stop)
+	})
+	<-w.done
+
+	l := w.logger
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	watchers := make([]*Watcher, 0, len(l.watchers))
+	for _, other := range l.watchers {
+		if other != w {
+			watchers = append(watchers, other)
+		}
+	}
+	l.watchers = watchers
+}
+
+func (w *Watcher) run() {
+	defer close(w.done)
+	for {
+		timer := w.clock.NewTimer(w.interval)
+		select {
+		case <-timer.C():
+			w.Check()
+		case <-w.stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// Check polls the configuration file once and applies it when it changed
+// since the last check. It reports whether a new configuration was applied.
+func (w *Watcher) Check() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	content, err := os.ReadFile(w.path)
+	if err != nil {
+		w.logger.Errorf("Failed to reload log configuration [ %s ], keeping the current one. Reason: %s", w.path, err)
+		return false
+	}
+
+	if bytes.Equal(content, w.content) {
+		return false
+	}
+	w.content = content
+
+	file, err := config.LoadFile(w.path)
+	var options config.LogOptions
+	if err == nil {
+		options, err = file.Options()
+	}
+	if err == nil {
+		options.Clock = w.logger.Options().Clock
+		changes := w.file.Diff(file)
+		if err = w.logger.Reconfigure(options); err == nil {
+			w.file = file
+			if len(changes) == 0 {
+				changes = []string{"no effective changes"}
+			}
+			w.logger.Infof("Reloaded log configuration [ %s ]: %s", w.path, strings.Join(changes, ", "))
+			return true
+		}
+	}
+
+	w.logger.Errorf("Failed to reload log configuration [ %s ], keeping the current one. Reason: %s", w.path, err)
+	return false
+}
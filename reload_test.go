@@ -0,0 +1,178 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for configuration reload
+
+package log_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/config"
+	"github.com/rish1988/go-log/cronjob"
+	"github.com/rish1988/go-log/logtest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWatch(t *testing.T) {
+	Convey("Given a logger watching its configuration file", t, func() {
+		path := filepath.Join(t.TempDir(), "log.yaml")
+		So(os.WriteFile(path, []byte("level: info\n"), 0600), ShouldBeNil)
+
+		logger, rec := logtest.New(t)
+		So(logger.Reconfigure(mustLoad(path)), ShouldBeNil)
+		w := logger.Watch(path, time.Hour)
+		defer w.Stop()
+
+		Convey("When the level is lowered in the file", func() {
+			So(os.WriteFile(path, []byte("level: debug\n"), 0600), ShouldBeNil)
+
+			Convey("It should apply it and log what changed", func() {
+				So(w.Check(), ShouldBeTrue)
+				So(logger.Level(), ShouldEqual, log.Debug)
+				rec.AssertLogged(t, log.Info, "Level: info -> debug")
+			})
+		})
+
+		Convey("When the file becomes invalid", func() {
+			So(os.WriteFile(path, []byte("level: verbose\n"), 0600), ShouldBeNil)
+
+			Convey("It should keep the current configuration", func() {
+				So(w.Check(), ShouldBeFalse)
+				So(logger.Level(), ShouldEqual, log.Info)
+				rec.AssertLogged(t, log.Error, "keeping the current one")
+			})
+		})
+
+		Convey("When the file is unchanged", func() {
+			Convey("It should not reload", func() {
+				So(w.Check(), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestWatchColors(t *testing.T) {
+	Convey("Given a watched configuration file with colors", t, func() {
+		path := filepath.Join(t.TempDir(), "log.yaml")
+		write := func(info string) {
+			content := "colors:\n  info: \"" + info + "\"\nprefixes:\n  warn:\n    color: red\n"
+			So(os.WriteFile(path, []byte(content), 0600), ShouldBeNil)
+		}
+		write("#ff0000")
+
+		logger, rec := logtest.New(t)
+		So(logger.Reconfigure(mustLoad(path)), ShouldBeNil)
+		w := logger.Watch(path, time.Hour)
+		defer w.Stop()
+
+		Convey("When only the hex color of a level changes", func() {
+			write("#00ff00")
+
+			Convey("It should report the color by name and leave the prefixes out", func() {
+				So(w.Check(), ShouldBeTrue)
+				rec.AssertLogged(t, log.Info, "Colors.Info: #ff0000 -> #00ff00")
+				for _, r := range rec.Records() {
+					So(r.Message, ShouldNotContainSubstring, "Prefixes")
+				}
+			})
+		})
+	})
+}
+
+func TestStopReconfigure(t *testing.T) {
+	Convey("Given a logger rotating on a shared scheduler", t, func() {
+		scheduler := cronjob.NewSchedulerWithLocation(time.UTC)
+		Reset(scheduler.Stop)
+		opts := config.LogOptions{
+			FileOptions: &config.FileOptions{
+				LogsDir:  t.TempDir(),
+				FileName: "app",
+				TimeZone: "UTC",
+				RotationPolicyOptions: &config.RotationPolicyOptions{
+					RotationInterval: "@midnight",
+					MaxFiles:         3,
+					Scheduler:        scheduler,
+				},
+			},
+		}
+		logger, err := log.Open(log.NewFdWriters(&pipe{}), opts)
+		So(err, ShouldBeNil)
+		path := filepath.Join(t.TempDir(), "log.yaml")
+		So(os.WriteFile(path, []byte("level: info\n"), 0600), ShouldBeNil)
+		w := logger.Watch(path, time.Millisecond)
+
+		Convey("When it is reconfigured while being stopped", func() {
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				logger.Reconfigure(opts)
+			}()
+			go func() {
+				defer wg.Done()
+				logger.Stop()
+			}()
+			wg.Wait()
+
+			Convey("It should leave no rotation job behind", func() {
+				So(scheduler.Len(), ShouldEqual, 0)
+			})
+
+			Convey("It should not schedule rotation again", func() {
+				So(logger.Reconfigure(opts), ShouldBeNil)
+				So(scheduler.Len(), ShouldEqual, 0)
+			})
+
+			Convey("It should have stopped its watcher", func() {
+				So(w.Stop, ShouldNotPanic)
+			})
+		})
+	})
+}
+
+func TestReconfigureStderr(t *testing.T) {
+	Convey("Given a file logger opened without writers", t, func() {
+		logger, err := log.Open(nil, config.LogOptions{
+			FileOptions: &config.FileOptions{
+				LogsDir:  t.TempDir(),
+				FileName: "app",
+				TimeZone: "UTC",
+			},
+		})
+		So(err, ShouldBeNil)
+		Reset(logger.Stop)
+
+		Convey("When it is reconfigured without the file", func() {
+			stderr, err := os.Create(filepath.Join(t.TempDir(), "stderr"))
+			So(err, ShouldBeNil)
+			prev := os.Stderr
+			os.Stderr = stderr
+			Reset(func() {
+				os.Stderr = prev
+				stderr.Close()
+			})
+			So(logger.Reconfigure(config.LogOptions{}), ShouldBeNil)
+			logger.Info("hello")
+
+			Convey("It should keep writing to stderr", func() {
+				data, err := os.ReadFile(stderr.Name())
+				So(err, ShouldBeNil)
+				So(string(data), ShouldEqual, "[INFO]  hello\n")
+			})
+		})
+	})
+}
+
+func mustLoad(path string) config.LogOptions {
+	opts, err := config.Load(path)
+	if err != nil {
+		panic(err)
+	}
+	return opts
+}
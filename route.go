@@ -0,0 +1,50 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import "strings"
+
+// NameFilter is implemented by writers taking the lines of some loggers only,
+// picked by logger name. See ForNames and ExceptNames.
+type NameFilter interface {
+	AcceptName(name string) bool
+}
+
+// nameFilter routes the lines of loggers to its writer by logger name
+type nameFilter struct {
+	FdWriter
+	names  []string
+	except bool
+}
+
+// ForNames returns a writer taking only the lines of the loggers named one of
+// names or named below them, e.g. "db" takes the lines of "db" and "db.pool"
+// but not the ones of unnamed loggers
+func ForNames(w FdWriter, names ...string) FdWriter {
+	return &nameFilter{FdWriter: w, names: names}
+}
+
+// ExceptNames returns a writer taking the lines of every logger except the
+// ones ForNames(w, names...) would take
+func ExceptNames(w FdWriter, names ...string) FdWriter {
+	return &nameFilter{FdWriter: w, names: names, except: true}
+}
+
+// AcceptName check whether the writer takes the lines of the logger named name
+func (f *nameFilter) AcceptName(name string) bool {
+	for _, n := range f.names {
+		if name == n || strings.HasPrefix(name, n+".") {
+			return !f.except
+		}
+	}
+	return f.except
+}
+
+// accepts check whether w takes the lines of the logger named name
+func accepts(w FdWriter, name string) bool {
+	if filter, ok := w.(NameFilter); ok {
+		return filter.AcceptName(name)
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/rish1988/go-log/buffer"
+	"github.com/rish1988/go-log/config"
+)
+
+// maxStackDepth limits the number of frames captured for a stack trace
+const maxStackDepth = 64
+
+// stackPolicy decides which records carry a stack trace and which frames it
+// shows
+type stackPolicy struct {
+	level        MessageType
+	hideRuntime  bool
+	hidePackages []string
+}
+
+// stackPolicyOf returns the stack policy of the options, nil when stack
+// traces are off
+func stackPolicyOf(options config.LogOptions) *stackPolicy {
+	if options.Stack == nil {
+		return nil
+	}
+	level, err := ParseLevel(options.Stack.Level)
+	if err != nil {
+		return nil
+	}
+	return &stackPolicy{
+		level:        level,
+		hideRuntime:  options.Stack.HideRuntime,
+		hidePackages: options.Stack.HidePackages,
+	}
+}
+
+func (l *Logger) getStackPolicy() *stackPolicy {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.stack
+}
+
+// capture returns the stack starting skip frames above its caller, leaving
+// out the frames of helpers on top and the hidden frames
+func (p *stackPolicy) capture(skip int) []Caller {
+	var pcs [maxStackDepth]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(skip+2, pcs[:])])
+
+	var stack []Caller
+	top := true
+	for {
+		f, more := frames.Next()
+		if top && isHelper(f.Function) {
+			if !more {
+				break
+			}
+			continue
+		}
+		top = false
+		if !p.hides(funcPackage(f.Function)) {
+			stack = append(stack, Caller{Function: f.Function, File: f.File, Line: f.Line})
+		}
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// hides check whether frames of package pkg are left out
+func (p *stackPolicy) hides(pkg string) bool {
+	// Standard library import paths have no dot in their first element
+	if p.hideRuntime && pkg != "main" && !strings.Contains(strings.SplitN(pkg, "/", 2)[0], ".") {
+		return true
+	}
+	for _, hidden := range p.hidePackages {
+		if pkg == hidden || strings.HasPrefix(pkg, hidden+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// appendStack append stack to buf as an indented block, one function and
+// location pair per frame
+func appendStack(buf *buffer.Buffer, stack []Caller) {
+	for _, c := range stack {
+		buf.AppendString("    ")
+		buf.AppendString(c.Function)
+		buf.AppendString("\n        ")
+		buf.AppendString(c.File)
+		buf.AppendByte(':')
+		buf.AppendInt(c.Line, 0)
+		buf.AppendByte('\n')
+	}
+}
+
+// appendDetails append the error details and the stack trace to both
+// buffers, gray when colored. The caller holds the lock.
+func (l *Logger) appendDetails(color bool, errs []ErrorInfo, stack []Caller) {
+	start := len(l.noColorBuf.Buffer)
+	appendErrors(&l.noColorBuf.Buffer, errs)
+	appendStack(&l.noColorBuf.Buffer, stack)
+	if start == len(l.noColorBuf.Buffer) {
+		return
+	}
+	if color {
+		l.colorBuf.Gray()
+	}
+	l.colorBuf.Append(l.noColorBuf.Buffer[start:])
+	if color {
+		l.colorBuf.Off()
+	}
+}
@@ -0,0 +1,61 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the stack traces
+
+package log_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStackTrace(t *testing.T) {
+	Convey("Given a logger attaching stack traces to errors", t, func() {
+		out := &pipe{}
+		logger, err := log.NewLogger(log.WithOutput(out), log.WithStackTrace(log.Error, true))
+		So(err, ShouldBeNil)
+
+		Convey("When an error and a warning are logged", func() {
+			logger.Error("failed")
+			logger.Warn("slow")
+
+			Convey("It should print the stack below the error only", func() {
+				lines := strings.Split(out.String(), "\n")
+				So(lines[0], ShouldEndWith, "failed")
+				So(lines[1], ShouldStartWith, "    github.com/rish1988/go-log_test.TestStackTrace")
+				So(lines[2], ShouldContainSubstring, "stack_test.go:")
+				So(out.String(), ShouldNotContainSubstring, "testing.tRunner")
+				So(lines[len(lines)-2], ShouldEqual, "[WARN]  slow")
+			})
+		})
+	})
+
+	Convey("Given a JSON logger attaching stack traces to errors", t, func() {
+		out := &pipe{}
+		logger, err := log.NewLogger(log.WithOutput(out), log.WithJSON(), log.WithStackTrace(log.Error, false))
+		So(err, ShouldBeNil)
+
+		Convey("When an error is logged", func() {
+			logger.Error("failed")
+
+			Convey("It should write the stack as an array of frames", func() {
+				var rec struct {
+					Stack []struct {
+						Function string
+						File     string
+						Line     int
+					}
+				}
+				So(json.Unmarshal(out.Bytes(), &rec), ShouldBeNil)
+				So(len(rec.Stack), ShouldBeGreaterThan, 1)
+				So(rec.Stack[0].Function, ShouldStartWith, "github.com/rish1988/go-log_test.TestStackTrace")
+				So(rec.Stack[len(rec.Stack)-1].Function, ShouldStartWith, "runtime.")
+			})
+		})
+	})
+}
@@ -0,0 +1,112 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"github.com/rish1988/go-log/colorful"
+	"github.com/rish1988/go-log/config"
+)
+
+// palette is the theme of a logger, with the escape sequences of the parts
+// appended straight to the buffers resolved once
+type palette struct {
+	levels     [Trace + 1]colorful.Color
+	timeStamp  []byte
+	caller     []byte
+	fieldKey   []byte
+	fieldValue []byte
+	names      [][]byte
+}
+
+// themeOf returns the theme named by the options, the default one when
+// unset or unknown, with the level and timestamp colors of the options
+// painted over it
+func themeOf(options config.LogOptions) colorful.Theme {
+	theme, err := colorful.ThemeByName(options.ColorOptions.Theme)
+	if len(options.ColorOptions.Theme) == 0 || err != nil {
+		theme, _ = colorful.ThemeByName("default")
+	}
+
+	for _, c := range []struct {
+		color colorful.Color
+		theme *colorful.Color
+	}{
+		{options.ColorOptions.Fatal, &theme.Fatal},
+		{options.ColorOptions.Error, &theme.Error},
+		{options.ColorOptions.Warn, &theme.Warn},
+		{options.ColorOptions.Info, &theme.Info},
+		{options.ColorOptions.Debug, &theme.Debug},
+		{options.ColorOptions.Trace, &theme.Trace},
+		{options.ColorOptions.TimeStampColorOptions.Color, &theme.TimeStamp},
+	} {
+		if c.color != nil {
+			*c.theme = c.color
+		}
+	}
+	return theme
+}
+
+// paletteOf resolves the theme of the options
+func paletteOf(options config.LogOptions) palette {
+	theme := themeOf(options)
+	p := palette{
+		levels: [Trace + 1]colorful.Color{
+			Fatal: theme.Fatal,
+			Error: theme.Error,
+			Warn:  theme.Warn,
+			Info:  theme.Info,
+			Debug: theme.Debug,
+			Trace: theme.Trace,
+		},
+		timeStamp:  colorful.Sequence(theme.TimeStamp),
+		caller:     colorful.Sequence(theme.Caller),
+		fieldKey:   colorful.Sequence(theme.FieldKey),
+		fieldValue: colorful.Sequence(theme.FieldValue),
+	}
+	for _, c := range theme.Names {
+		p.names = append(p.names, colorful.Sequence(c))
+	}
+	return p
+}
+
+// level returns the color of level, nil when the level has none
+func (p *palette) level(level MessageType) colorful.Color {
+	if level < Fatal || level > Trace {
+		return nil
+	}
+	return p.levels[level]
+}
+
+// fields check whether the field keys or values are painted
+func (p *palette) fields() bool {
+	return len(p.fieldKey) != 0 || len(p.fieldValue) != 0
+}
+
+// name returns the sequence of name, the same for every line
+func (p *palette) name(name string) []byte {
+	if len(p.names) == 0 {
+		return nil
+	}
+	// FNV-1a, inlined to stay free of allocations
+	hash := uint32(2166136261)
+	for i := 0; i < len(name); i++ {
+		hash ^= uint32(name[i])
+		hash *= 16777619
+	}
+	return p.names[hash%uint32(len(p.names))]
+}
+
+// appendPainted copy what was appended to the plain buffer since start to
+// the colored buffer, painted with seq when colored. The caller holds the
+// lock.
+func (l *Logger) appendPainted(color bool, seq []byte, start int) {
+	paint := color && len(seq) != 0
+	if paint {
+		l.colorBuf.Append(seq)
+	}
+	l.colorBuf.Append(l.noColorBuf.Buffer[start:])
+	if paint {
+		l.colorBuf.Off()
+	}
+}
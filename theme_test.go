@@ -0,0 +1,103 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the color themes
+
+package log_test
+
+import (
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/colorful"
+	"github.com/rish1988/go-log/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTheme(t *testing.T) {
+	Convey("Given a logger with the monochrome theme", t, func() {
+		out := &pipe{}
+		logger, err := log.NewLogger(log.WithOutput(out), log.WithTheme("monochrome"))
+		So(err, ShouldBeNil)
+
+		Convey("When it logs a warning with color forced on", func() {
+			logger.WithColor().Warn("slow")
+
+			Convey("It should underline the warning instead of coloring it", func() {
+				So(out.String(), ShouldEqual, "\033[0;4m[WARN]  \033[0m\033[0;4mslow\n\033[0m")
+			})
+		})
+
+		Convey("When it logs without color", func() {
+			logger.Warn("slow")
+
+			Convey("It should print the plain line", func() {
+				So(out.String(), ShouldEqual, "[WARN]  slow\n")
+			})
+		})
+	})
+
+	Convey("Given a logger with the solarized theme and fields", t, func() {
+		out := &pipe{}
+		logger, _ := log.NewLogger(log.WithOutput(out), log.WithTheme("solarized"))
+		logger = logger.WithFields(log.Fields{"user": "bob"})
+
+		Convey("When it logs with color forced on", func() {
+			logger.WithColor().Info("login")
+
+			Convey("It should paint the field key and value apart from the message", func() {
+				line := out.String()
+				So(line, ShouldContainSubstring, "user\033[0m=")
+				So(line, ShouldContainSubstring, "bob\033[0m\n")
+				So(line, ShouldNotContainSubstring, " user=bob")
+			})
+		})
+
+		Convey("When it logs without color", func() {
+			logger.Info("login")
+
+			Convey("It should print the fields as usual", func() {
+				So(out.String(), ShouldEqual, "[INFO]  login user=bob\n")
+			})
+		})
+	})
+
+	Convey("Given a timestamp color and a theme", t, func() {
+		out := &pipe{}
+		logger := log.New(log.NewFdWriters(out), config.LogOptions{
+			ColorOptions: config.ColorOptions{
+				Color: true,
+				Theme: "colorblind-safe",
+				TimeStampColorOptions: config.TimeStampColorOptions{
+					TimeStamp: true,
+					Color:     colorful.Red,
+				},
+				Info: colorful.Cyan,
+			},
+		})
+
+		Convey("When it logs", func() {
+			logger.Info("ready")
+
+			Convey("It should prefer the colors of the options over the theme", func() {
+				So(out.String(), ShouldStartWith, "\033[0;36m[INFO]  \033[0m\033[0;31m")
+				So(out.String(), ShouldEndWith, "\033[0;36mready\n\033[0m")
+			})
+		})
+	})
+
+	Convey("Given a logger without a theme", t, func() {
+		out := &pipe{}
+		logger := log.New(log.NewFdWriters(out), config.LogOptions{
+			ColorOptions: config.ColorOptions{Color: true},
+		})
+
+		Convey("When it logs a warning", func() {
+			logger.Warn("slow")
+
+			Convey("It should use the default colors", func() {
+				So(out.String(), ShouldEqual, "\033[0;33m[WARN]  \033[0m\033[0;33mslow\n\033[0m")
+			})
+		})
+	})
+}
@@ -0,0 +1,78 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/rish1988/go-log/config"
+)
+
+// timeStamp is the compiled timestamp format of a logger
+type timeStamp struct {
+	// Go time layout, unused for Unix epoch formats
+	layout string
+	// Unit of Unix epoch formats, zero for layouts
+	unix time.Duration
+}
+
+// timePresets are the named time formats, precision is appended to the ones
+// ending in seconds
+var timePresets = map[string]string{
+	"rfc3339": "2006-01-02T15:04:05Z07:00",
+	"iso8601": "2006-01-02T15:04:05Z0700",
+}
+
+// unixFormats are the Unix epoch formats and their unit
+var unixFormats = map[string]time.Duration{
+	"unix":      time.Second,
+	"unixmilli": time.Millisecond,
+	"unixmicro": time.Microsecond,
+	"unixnano":  time.Nanosecond,
+}
+
+// fractions are the fractional second layouts of each precision
+var fractions = map[string]string{
+	"":   "",
+	"s":  "",
+	"ms": ".000",
+	"us": ".000000",
+	"µs": ".000000",
+	"ns": ".000000000",
+}
+
+// timeStampOf compiles the timestamp format of the options. The date format
+// and time of day are printed by default, at the configured precision.
+func timeStampOf(options config.LogOptions, dateFormat string) timeStamp {
+	return parseTimeStamp(options.TimeFormat, options.TimePrecision, dateFormat)
+}
+
+// parseTimeStamp compiles format, a preset name or Go time layout
+func parseTimeStamp(format, precision, dateFormat string) timeStamp {
+	if unit, ok := unixFormats[format]; ok {
+		return timeStamp{unix: unit}
+	}
+
+	fraction := fractions[precision]
+	preset, ok := timePresets[format]
+	switch {
+	case ok && len(fraction) == 0:
+		return timeStamp{layout: preset}
+	case ok:
+		// The fraction goes between the seconds and the zone
+		return timeStamp{layout: preset[:19] + fraction + preset[19:]}
+	case len(format) != 0:
+		return timeStamp{layout: format}
+	}
+	return timeStamp{layout: dateFormat + " 15:04:05" + fraction}
+}
+
+// appendTo append t to buf without allocating when buf has room
+func (s timeStamp) appendTo(buf []byte, t time.Time) []byte {
+	if s.unix != 0 {
+		return strconv.AppendInt(buf, t.UnixNano()/int64(s.unix), 10)
+	}
+	return t.AppendFormat(buf, s.layout)
+}
@@ -0,0 +1,67 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the timestamp formats
+
+package log_test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/clock/clocktest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// discard is an FdWriter dropping everything written to it
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) {
+	return io.Discard.Write(p)
+}
+
+func (discard) Fd() uintptr {
+	return ^uintptr(0)
+}
+
+func TestTimeStamp(t *testing.T) {
+	zone := time.FixedZone("CET", 3600)
+	clk := clocktest.NewFake(time.Date(2026, 1, 2, 15, 4, 5, 123456789, zone))
+
+	Convey("Given loggers with different timestamp formats", t, func() {
+		for _, c := range []struct {
+			options []log.Option
+			expect  string
+		}{
+			{[]log.Option{log.WithTimestamp()}, "02-Jan-2026 14:04:05 "},
+			{[]log.Option{log.WithTimePrecision("ms")}, "02-Jan-2026 14:04:05.123 "},
+			{[]log.Option{log.WithTimeFormat("rfc3339"), log.WithTimePrecision("us")}, "2026-01-02T14:04:05.123456Z "},
+			{[]log.Option{log.WithTimeFormat("iso8601"), log.WithTimePrecision("ns")}, "2026-01-02T14:04:05.123456789Z "},
+			{[]log.Option{log.WithTimeFormat("unixmilli")}, "1767362645123 "},
+			{[]log.Option{log.WithTimeFormat(time.Kitchen)}, "2:04PM "},
+		} {
+			out := &pipe{}
+			logger, err := log.NewLogger(append(c.options, log.WithOutput(out), log.WithClock(clk), log.WithUTC())...)
+			So(err, ShouldBeNil)
+
+			logger.Info("tick")
+			So(out.String(), ShouldEqual, "[INFO]  "+c.expect+"tick\n")
+		}
+	})
+
+	Convey("Given a timestamped logger without color", t, func() {
+		logger, err := log.NewLogger(log.WithOutput(discard{}), log.WithClock(clk), log.WithTimePrecision("ns"))
+		So(err, ShouldBeNil)
+		logger = logger.WithoutColor()
+		message := log.Message{Plain: []byte("tick\n"), Color: []byte("tick\n")}
+
+		Convey("It should write without allocating", func() {
+			allocs := testing.AllocsPerRun(100, func() {
+				logger.Output(1, log.InfoPrefix, message)
+			})
+			So(allocs, ShouldEqual, 0)
+		})
+	})
+}
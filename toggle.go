@@ -0,0 +1,82 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+
+package log
+
+import "github.com/rish1988/go-log/config"
+
+// colorMode tells whether color follows terminal detection or is forced
+type colorMode int
+
+const (
+	colorAuto colorMode = iota
+	colorAlways
+	colorNever
+)
+
+// colorModeOf returns the color mode requested by the options
+func colorModeOf(options config.LogOptions) colorMode {
+	switch {
+	case options.NoColor:
+		return colorNever
+	case options.Color:
+		return colorAlways
+	}
+	return colorAuto
+}
+
+// WithColor force colored output on every writer, even when it is not a
+// terminal. Like the other toggles it applies to l and the loggers sharing
+// its settings, see Named.
+func (l *Logger) WithColor() *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.colorMode = colorAlways
+	return l
+}
+
+// WithoutColor disable colored output on every writer
+func (l *Logger) WithoutColor() *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.colorMode = colorNever
+	return l
+}
+
+// WithAutoColor decide color per writer from the environment and terminal
+// detection, which is the default. See ColorEnabled.
+func (l *Logger) WithAutoColor() *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.colorMode = colorAuto
+	return l
+}
+
+// WithDebug enable the debug and trace output
+func (l *Logger) WithDebug() *Logger {
+	l.SetLevel(Trace)
+	return l
+}
+
+// WithoutDebug disable the debug and trace output
+func (l *Logger) WithoutDebug() *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.level > Info {
+		l.level = Info
+		l.options.Level = Info.Name()
+	}
+	return l
+}
+
+// Quiet suppress all log output, Fatal still quits the program
+func (l *Logger) Quiet() *Logger {
+	l.SetQuiet(true)
+	return l
+}
+
+// NoQuiet re-enable the log output
+func (l *Logger) NoQuiet() *Logger {
+	l.SetQuiet(false)
+	return l
+}
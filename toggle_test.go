@@ -0,0 +1,76 @@
+// The colorful and simple logging library
+// Copyright (c) 2017 Fadhli Dzil Ikram
+//
+// Test file for the runtime toggles
+
+package log_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	log "github.com/rish1988/go-log"
+	"github.com/rish1988/go-log/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// pipe is an FdWriter that is never detected as a terminal
+type pipe struct {
+	bytes.Buffer
+}
+
+func (p *pipe) Fd() uintptr {
+	return ^uintptr(0)
+}
+
+// TestMain clears the color variables, so the tests writing to pipes do not
+// depend on the environment they run in
+func TestMain(m *testing.M) {
+	for _, name := range colorEnv {
+		os.Unsetenv(name)
+	}
+	os.Exit(m.Run())
+}
+
+func TestToggles(t *testing.T) {
+	Convey("Given a logger writing to a pipe", t, func() {
+		out := &pipe{}
+		logger := log.New(log.NewFdWriters(out), config.LogOptions{})
+
+		Convey("When color is forced on", func() {
+			logger.WithColor().Info("colored")
+
+			Convey("It should write escape sequences", func() {
+				So(out.String(), ShouldContainSubstring, "\033[0;32m")
+			})
+		})
+
+		Convey("When color is forced on and off again", func() {
+			logger.WithColor().WithoutColor().Info("plain")
+
+			Convey("It should write plain text", func() {
+				So(out.String(), ShouldEqual, "[INFO]  plain\n")
+			})
+		})
+
+		Convey("When debug is toggled on and off", func() {
+			logger.WithDebug().Debug("shown")
+			logger.WithoutDebug().Debug("hidden")
+
+			Convey("It should only write the debug message logged while enabled", func() {
+				So(out.String(), ShouldContainSubstring, "shown")
+				So(out.String(), ShouldNotContainSubstring, "hidden")
+			})
+		})
+
+		Convey("When the logger is quieted", func() {
+			logger.Quiet().Info("hidden")
+			logger.NoQuiet().Info("shown")
+
+			Convey("It should only write once no longer quiet", func() {
+				So(out.String(), ShouldEqual, "[INFO]  shown\n")
+			})
+		})
+	})
+}